@@ -0,0 +1,223 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+)
+
+// HueRotate returns a copy of src with the hue rotated by the angle in degrees.
+func HueRotate(src image.Image, degrees float64) *image.NRGBA {
+	return adjust(src, func(r, g, b float64) (float64, float64, float64) {
+		h, s, l := rgbToHSL(r, g, b)
+
+		return hslToRGB(h+degrees, s, l)
+	})
+}
+
+// Saturate returns a copy of src with the amount (range [-1, 1]) added to the
+// HSL saturation of each pixel. A negative amount desaturates the image.
+func Saturate(src image.Image, amount float64) *image.NRGBA {
+	return adjust(src, func(r, g, b float64) (float64, float64, float64) {
+		h, s, l := rgbToHSL(r, g, b)
+
+		return hslToRGB(h, s+amount, l)
+	})
+}
+
+// Desaturate returns a copy of src with the amount (range [-1, 1])
+// subtracted from the HSL saturation of each pixel.
+func Desaturate(src image.Image, amount float64) *image.NRGBA {
+	return Saturate(src, -amount)
+}
+
+// Lighten returns a copy of src with the amount (range [-1, 1]) added to the
+// HSL lightness of each pixel. A negative amount darkens the image.
+func Lighten(src image.Image, amount float64) *image.NRGBA {
+	return adjust(src, func(r, g, b float64) (float64, float64, float64) {
+		h, s, l := rgbToHSL(r, g, b)
+
+		return hslToRGB(h, s, l+amount)
+	})
+}
+
+// Darken returns a copy of src with the amount (range [-1, 1])
+// subtracted from the HSL lightness of each pixel.
+func Darken(src image.Image, amount float64) *image.NRGBA {
+	return Lighten(src, -amount)
+}
+
+// Contrast returns a copy of src with the contrast scaled by the factor around
+// middle gray, where 1 leaves the image unchanged and 0 results in middle gray.
+func Contrast(src image.Image, factor float64) *image.NRGBA {
+	return adjustComponents(src, func(v float64) float64 {
+		return (v-0.5)*factor + 0.5
+	})
+}
+
+// Gamma returns a copy of src with the gamma correction applied, where
+// values above 1 brighten and values below 1 darken the image.
+func Gamma(src image.Image, gamma float64) *image.NRGBA {
+	return adjustComponents(src, func(v float64) float64 {
+		return math.Pow(v, 1/gamma)
+	})
+}
+
+// Levels returns a copy of src with the input range [inBlack, inWhite] mapped
+// to the output range [outBlack, outWhite] with the gamma correction applied
+// in between. All values are in the range [0, 1], and a gamma of 1 is linear.
+func Levels(src image.Image, inBlack, inWhite, gamma, outBlack, outWhite float64) *image.NRGBA {
+	return adjustComponents(src, func(v float64) float64 {
+		switch {
+		case inWhite > inBlack:
+			v = Clamp((v-inBlack)/(inWhite-inBlack), 0, 1)
+		case v < inBlack:
+			v = 0
+		default:
+			v = 1
+		}
+
+		return Lerp(outBlack, outWhite, math.Pow(v, 1/gamma))
+	})
+}
+
+// Curve maps a component value in the range [0, 1] to a new value.
+type Curve func(float64) float64
+
+// NewCurve creates a Curve passing through the points (range [0, 1]) using
+// monotone cubic interpolation, like the curves tool in image editors.
+//
+// The curve is flat outside of the first and last points, and a curve
+// without points is the identity.
+func NewCurve(points ...Vec) Curve {
+	if len(points) == 0 {
+		return func(v float64) float64 { return v }
+	}
+
+	p := append([]Vec(nil), points...)
+
+	sort.Slice(p, func(i, j int) bool { return p[i].X < p[j].X })
+
+	n := len(p)
+
+	if n == 1 {
+		return func(float64) float64 { return p[0].Y }
+	}
+
+	// Secant slopes between the points.
+	d := make([]float64, n-1)
+
+	for i := 0; i < n-1; i++ {
+		if dx := p[i+1].X - p[i].X; dx > 0 {
+			d[i] = (p[i+1].Y - p[i].Y) / dx
+		}
+	}
+
+	// Tangents at the points, limited to keep the curve
+	// monotone between the points. (Fritsch–Carlson)
+	m := make([]float64, n)
+
+	m[0], m[n-1] = d[0], d[n-2]
+
+	for i := 1; i < n-1; i++ {
+		if d[i-1]*d[i] > 0 {
+			m[i] = (d[i-1] + d[i]) / 2
+		}
+	}
+
+	for i := 0; i < n-1; i++ {
+		if d[i] == 0 {
+			m[i], m[i+1] = 0, 0
+
+			continue
+		}
+
+		a, b := m[i]/d[i], m[i+1]/d[i]
+
+		if s := a*a + b*b; s > 9 {
+			t := 3 / math.Sqrt(s)
+
+			m[i], m[i+1] = t*a*d[i], t*b*d[i]
+		}
+	}
+
+	return func(v float64) float64 {
+		switch {
+		case v <= p[0].X:
+			return p[0].Y
+		case v >= p[n-1].X:
+			return p[n-1].Y
+		}
+
+		i := sort.Search(n-1, func(i int) bool { return p[i+1].X >= v })
+
+		h := p[i+1].X - p[i].X
+
+		if h == 0 {
+			return p[i+1].Y
+		}
+
+		t := (v - p[i].X) / h
+		t2, t3 := t*t, t*t*t
+
+		return (2*t3-3*t2+1)*p[i].Y + (t3-2*t2+t)*h*m[i] +
+			(-2*t3+3*t2)*p[i+1].Y + (t3-t2)*h*m[i+1]
+	}
+}
+
+// Curves returns a copy of src with the curve applied to the R, G and B components.
+func Curves(src image.Image, c Curve) *image.NRGBA {
+	return adjustComponents(src, c)
+}
+
+// adjustComponents returns a copy of src with fn applied
+// to each of the non-premultiplied R, G and B components.
+func adjustComponents(src image.Image, fn func(float64) float64) *image.NRGBA {
+	var lut [256]uint8
+
+	for i := range lut {
+		lut[i] = uint8(math.Round(Clamp(fn(float64(i)/0xFF), 0, 1) * 0xFF))
+	}
+
+	b := src.Bounds()
+	dst := image.NewNRGBA(b)
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(src.At(x, y)).(color.NRGBA)
+
+			dst.SetNRGBA(x, y, color.NRGBA{lut[c.R], lut[c.G], lut[c.B], c.A})
+		}
+	}
+
+	return dst
+}
+
+// adjust returns a copy of src with fn applied to the non-premultiplied
+// R, G and B (range [0, 1]) of each pixel, keeping the alpha.
+func adjust(src image.Image, fn func(r, g, b float64) (float64, float64, float64)) *image.NRGBA {
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(bounds)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := floatNRGBA(src.At(x, y))
+
+			if a == 0 {
+				continue
+			}
+
+			r, g, b = fn(r, g, b)
+
+			dst.SetNRGBA(x, y, color.NRGBA{
+				uint8(math.Round(Clamp(r, 0, 1) * 0xFF)),
+				uint8(math.Round(Clamp(g, 0, 1) * 0xFF)),
+				uint8(math.Round(Clamp(b, 0, 1) * 0xFF)),
+				uint8(math.Round(a * 0xFF)),
+			})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,72 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestAdjust(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(1, 1, 3, 2))
+
+	src.SetNRGBA(1, 1, color.NRGBA{255, 0, 0, 255})
+	src.SetNRGBA(2, 1, color.NRGBA{64, 128, 192, 128})
+
+	for _, tc := range []struct {
+		name string
+		dst  *image.NRGBA
+		want [2]color.NRGBA
+	}{
+		{"HueRotate", HueRotate(src, 120), [2]color.NRGBA{{0, 255, 0, 255}, {192, 64, 128, 128}}},
+		{"Desaturate", Desaturate(src, 1), [2]color.NRGBA{{128, 128, 128, 255}, {128, 128, 128, 128}}},
+		{"Saturate", Saturate(src, 1), [2]color.NRGBA{{255, 0, 0, 255}, {1, 128, 255, 128}}},
+		{"Lighten", Lighten(src, 0.25), [2]color.NRGBA{{255, 128, 128, 255}, {160, 192, 224, 128}}},
+		{"Darken", Darken(src, 1), [2]color.NRGBA{{0, 0, 0, 255}, {0, 0, 0, 128}}},
+		{"Contrast", Contrast(src, 0), [2]color.NRGBA{{128, 128, 128, 255}, {128, 128, 128, 128}}},
+		{"Gamma", Gamma(src, 1), [2]color.NRGBA{{255, 0, 0, 255}, {64, 128, 192, 128}}},
+		{"Levels", Levels(src, 0.25, 0.75, 1, 0, 1), [2]color.NRGBA{{255, 0, 0, 255}, {0, 129, 255, 128}}},
+		{"Curves", Curves(src, NewCurve(V(0, 1), V(1, 0))), [2]color.NRGBA{{0, 255, 255, 255}, {191, 127, 63, 128}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := tc.dst.Bounds(), src.Bounds(); got != want {
+				t.Fatalf("Bounds() = %v, want %v", got, want)
+			}
+
+			for i, want := range tc.want {
+				if got := tc.dst.NRGBAAt(i+1, 1); got != want {
+					t.Fatalf("NRGBAAt(%d, 1) = %v, want %v", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewCurve(t *testing.T) {
+	c := NewCurve(V(0, 0), V(0.25, 0.5), V(0.5, 0.6), V(1, 1))
+
+	for _, tc := range []struct {
+		v, want float64
+	}{
+		{-1, 0}, {0, 0}, {0.25, 0.5}, {0.5, 0.6}, {1, 1}, {2, 1},
+	} {
+		if got := c(tc.v); math.Abs(got-tc.want) > 1e-12 {
+			t.Fatalf("c(%v) = %v, want %v", tc.v, got, tc.want)
+		}
+	}
+
+	// The curve is monotone between the points.
+	for v, prev := 0.0, -1.0; v <= 1; v += 0.01 {
+		got := c(v)
+
+		if got < prev {
+			t.Fatalf("c(%v) = %v, less than %v", v, got, prev)
+		}
+
+		prev = got
+	}
+
+	if got, want := NewCurve()(0.3), 0.3; got != want {
+		t.Fatalf("NewCurve()(0.3) = %v, want %v", got, want)
+	}
+}
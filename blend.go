@@ -0,0 +1,372 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// BlendMode specifies how a source color is composited with a destination color.
+//
+// The Porter-Duff operators and blend modes are based on
+// https://www.w3.org/TR/compositing-1/
+type BlendMode int
+
+// Porter-Duff compositing operators.
+const (
+	// SrcOverBlendMode draws the source over the destination. (Default)
+	SrcOverBlendMode BlendMode = iota
+
+	// ClearBlendMode clears the destination.
+	ClearBlendMode
+
+	// SrcBlendMode replaces the destination with the source.
+	SrcBlendMode
+
+	// DstBlendMode leaves the destination untouched.
+	DstBlendMode
+
+	// DstOverBlendMode draws the destination over the source.
+	DstOverBlendMode
+
+	// SrcInBlendMode shows the source where the destination is opaque.
+	SrcInBlendMode
+
+	// DstInBlendMode shows the destination where the source is opaque.
+	DstInBlendMode
+
+	// SrcOutBlendMode shows the source where the destination is transparent.
+	SrcOutBlendMode
+
+	// DstOutBlendMode shows the destination where the source is transparent.
+	DstOutBlendMode
+
+	// SrcAtopBlendMode draws the source over the destination, only where the destination is opaque.
+	SrcAtopBlendMode
+
+	// DstAtopBlendMode draws the destination over the source, only where the source is opaque.
+	DstAtopBlendMode
+
+	// XorBlendMode shows the source and destination where they do not overlap.
+	XorBlendMode
+
+	// PlusBlendMode adds the source and destination together.
+	PlusBlendMode
+)
+
+// Separable blend modes.
+const (
+	// MultiplyBlendMode multiplies the source and destination colors.
+	MultiplyBlendMode BlendMode = iota + 32
+
+	// ScreenBlendMode multiplies the complements of the source and destination colors.
+	ScreenBlendMode
+
+	// OverlayBlendMode multiplies or screens the colors, depending on the destination color.
+	OverlayBlendMode
+
+	// DarkenBlendMode selects the darker of the source and destination colors.
+	DarkenBlendMode
+
+	// LightenBlendMode selects the lighter of the source and destination colors.
+	LightenBlendMode
+
+	// ColorDodgeBlendMode brightens the destination color to reflect the source color.
+	ColorDodgeBlendMode
+
+	// ColorBurnBlendMode darkens the destination color to reflect the source color.
+	ColorBurnBlendMode
+
+	// HardLightBlendMode multiplies or screens the colors, depending on the source color.
+	HardLightBlendMode
+
+	// SoftLightBlendMode darkens or lightens the colors, depending on the source color.
+	SoftLightBlendMode
+
+	// DifferenceBlendMode subtracts the darker of the two colors from the lighter color.
+	DifferenceBlendMode
+
+	// ExclusionBlendMode is similar to DifferenceBlendMode, but lower in contrast.
+	ExclusionBlendMode
+)
+
+// Non-separable blend modes.
+const (
+	// HueBlendMode uses the hue of the source with the saturation and luminosity of the destination.
+	HueBlendMode BlendMode = iota + 64
+
+	// SaturationBlendMode uses the saturation of the source with the hue and luminosity of the destination.
+	SaturationBlendMode
+
+	// ColorBlendMode uses the hue and saturation of the source with the luminosity of the destination.
+	ColorBlendMode
+
+	// LuminosityBlendMode uses the luminosity of the source with the hue and saturation of the destination.
+	LuminosityBlendMode
+)
+
+// Blend composites the src color with the dst color.
+func (bm BlendMode) Blend(dst, src color.Color) color.RGBA64 {
+	sr, sg, sb, sa := src.RGBA()
+	dr, dg, db, da := dst.RGBA()
+
+	s := [4]float64{float64(sr) / 0xFFFF, float64(sg) / 0xFFFF, float64(sb) / 0xFFFF, float64(sa) / 0xFFFF}
+	d := [4]float64{float64(dr) / 0xFFFF, float64(dg) / 0xFFFF, float64(db) / 0xFFFF, float64(da) / 0xFFFF}
+
+	var o [4]float64
+
+	if bm < MultiplyBlendMode {
+		fa, fb := bm.porterDuff(s[3], d[3])
+
+		for i := range o {
+			o[i] = s[i]*fa + d[i]*fb
+		}
+
+		if bm == PlusBlendMode {
+			for i := range o {
+				o[i] = math.Min(o[i], 1)
+			}
+		}
+	} else {
+		b := bm.blend(unpremultiply(d), unpremultiply(s))
+
+		for i := 0; i < 3; i++ {
+			o[i] = s[i]*(1-d[3]) + d[i]*(1-s[3]) + s[3]*d[3]*b[i]
+		}
+
+		o[3] = s[3] + d[3] - s[3]*d[3]
+	}
+
+	return color.RGBA64{
+		uint16(math.Round(Clamp(o[0], 0, o[3]) * 0xFFFF)),
+		uint16(math.Round(Clamp(o[1], 0, o[3]) * 0xFFFF)),
+		uint16(math.Round(Clamp(o[2], 0, o[3]) * 0xFFFF)),
+		uint16(math.Round(Clamp(o[3], 0, 1) * 0xFFFF)),
+	}
+}
+
+// porterDuff returns the fractions of the source and destination to use.
+func (bm BlendMode) porterDuff(as, ad float64) (fa, fb float64) {
+	switch bm {
+	case ClearBlendMode:
+		return 0, 0
+	case SrcBlendMode:
+		return 1, 0
+	case DstBlendMode:
+		return 0, 1
+	case DstOverBlendMode:
+		return 1 - ad, 1
+	case SrcInBlendMode:
+		return ad, 0
+	case DstInBlendMode:
+		return 0, as
+	case SrcOutBlendMode:
+		return 1 - ad, 0
+	case DstOutBlendMode:
+		return 0, 1 - as
+	case SrcAtopBlendMode:
+		return ad, 1 - as
+	case DstAtopBlendMode:
+		return 1 - ad, as
+	case XorBlendMode:
+		return 1 - ad, 1 - as
+	case PlusBlendMode:
+		return 1, 1
+	default:
+		return 1, 1 - as
+	}
+}
+
+// blend returns the mixed backdrop (cb) and source (cs) colors.
+func (bm BlendMode) blend(cb, cs [3]float64) [3]float64 {
+	switch bm {
+	case HueBlendMode:
+		return setLum(setSat(cs, sat(cb)), lum(cb))
+	case SaturationBlendMode:
+		return setLum(setSat(cb, sat(cs)), lum(cb))
+	case ColorBlendMode:
+		return setLum(cs, lum(cb))
+	case LuminosityBlendMode:
+		return setLum(cb, lum(cs))
+	}
+
+	var b [3]float64
+
+	for i := range b {
+		b[i] = bm.blendComponent(cb[i], cs[i])
+	}
+
+	return b
+}
+
+func (bm BlendMode) blendComponent(cb, cs float64) float64 {
+	switch bm {
+	case MultiplyBlendMode:
+		return cb * cs
+	case ScreenBlendMode:
+		return cb + cs - cb*cs
+	case OverlayBlendMode:
+		return HardLightBlendMode.blendComponent(cs, cb)
+	case DarkenBlendMode:
+		return math.Min(cb, cs)
+	case LightenBlendMode:
+		return math.Max(cb, cs)
+	case ColorDodgeBlendMode:
+		switch {
+		case cb == 0:
+			return 0
+		case cs == 1:
+			return 1
+		default:
+			return math.Min(1, cb/(1-cs))
+		}
+	case ColorBurnBlendMode:
+		switch {
+		case cb == 1:
+			return 1
+		case cs == 0:
+			return 0
+		default:
+			return 1 - math.Min(1, (1-cb)/cs)
+		}
+	case HardLightBlendMode:
+		if cs <= 0.5 {
+			return cb * 2 * cs
+		}
+
+		return ScreenBlendMode.blendComponent(cb, 2*cs-1)
+	case SoftLightBlendMode:
+		if cs <= 0.5 {
+			return cb - (1-2*cs)*cb*(1-cb)
+		}
+
+		d := math.Sqrt(cb)
+
+		if cb <= 0.25 {
+			d = ((16*cb-12)*cb + 4) * cb
+		}
+
+		return cb + (2*cs-1)*(d-cb)
+	case DifferenceBlendMode:
+		return math.Abs(cb - cs)
+	case ExclusionBlendMode:
+		return cb + cs - 2*cb*cs
+	default:
+		return cs
+	}
+}
+
+func unpremultiply(c [4]float64) [3]float64 {
+	if c[3] == 0 {
+		return [3]float64{}
+	}
+
+	return [3]float64{c[0] / c[3], c[1] / c[3], c[2] / c[3]}
+}
+
+func lum(c [3]float64) float64 {
+	return 0.3*c[0] + 0.59*c[1] + 0.11*c[2]
+}
+
+func clipColor(c [3]float64) [3]float64 {
+	l := lum(c)
+	n := math.Min(c[0], math.Min(c[1], c[2]))
+	x := math.Max(c[0], math.Max(c[1], c[2]))
+
+	for i := range c {
+		if n < 0 {
+			c[i] = l + (c[i]-l)*l/(l-n)
+		}
+
+		if x > 1 {
+			c[i] = l + (c[i]-l)*(1-l)/(x-l)
+		}
+	}
+
+	return c
+}
+
+func setLum(c [3]float64, l float64) [3]float64 {
+	d := l - lum(c)
+
+	return clipColor([3]float64{c[0] + d, c[1] + d, c[2] + d})
+}
+
+func sat(c [3]float64) float64 {
+	return math.Max(c[0], math.Max(c[1], c[2])) - math.Min(c[0], math.Min(c[1], c[2]))
+}
+
+func setSat(c [3]float64, s float64) [3]float64 {
+	maxi, mini := 0, 0
+
+	for i := range c {
+		if c[i] > c[maxi] {
+			maxi = i
+		}
+
+		if c[i] < c[mini] {
+			mini = i
+		}
+	}
+
+	if maxi == mini {
+		return [3]float64{}
+	}
+
+	mid := 3 - maxi - mini
+
+	var o [3]float64
+
+	o[mid] = (c[mid] - c[mini]) * s / (c[maxi] - c[mini])
+	o[maxi] = s
+
+	return o
+}
+
+// drawOp returns the draw.Op equivalent to the blend mode, if there is one.
+func (bm BlendMode) drawOp() (draw.Op, bool) {
+	switch bm {
+	case SrcOverBlendMode:
+		return draw.Over, true
+	case SrcBlendMode:
+		return draw.Src, true
+	default:
+		return 0, false
+	}
+}
+
+// DrawBlend draws src on dst using the given blend mode.
+func DrawBlend(dst draw.Image, r image.Rectangle, src image.Image, sp image.Point, bm BlendMode) {
+	if op, ok := bm.drawOp(); ok {
+		draw.Draw(dst, r, src, sp, op)
+		return
+	}
+
+	dx, dy := sp.X-r.Min.X, sp.Y-r.Min.Y
+
+	r = r.Intersect(dst.Bounds())
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			s := src.At(x+dx, y+dy)
+
+			dst.Set(x, y, bm.Blend(dst.At(x, y), s))
+		}
+	}
+}
+
+// DrawColorBlend draws an image.Rectangle of uniform color on dst using the given blend mode.
+func DrawColorBlend(dst draw.Image, r image.Rectangle, c color.Color, bm BlendMode) {
+	DrawBlend(dst, r, NewUniform(c), ZP, bm)
+}
+
+// MixBlend the current pixel color at x and y with the given color using the given blend mode.
+func MixBlend(m draw.Image, x, y int, c color.Color, bm BlendMode) {
+	if bm == SrcOverBlendMode {
+		Mix(m, x, y, c)
+		return
+	}
+
+	m.Set(x, y, bm.Blend(m.At(x, y), c))
+}
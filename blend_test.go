@@ -0,0 +1,95 @@
+package gfx
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestBlendModeBlend(t *testing.T) {
+	var (
+		red   = ColorNRGBA(255, 0, 0, 255)
+		gray  = ColorNRGBA(128, 128, 128, 255)
+		blue  = ColorNRGBA(0, 0, 255, 128)
+		empty = ColorTransparent
+	)
+
+	for _, tc := range []struct {
+		bm   BlendMode
+		dst  color.Color
+		src  color.Color
+		want color.NRGBA
+	}{
+		{SrcOverBlendMode, red, blue, ColorNRGBA(127, 0, 128, 255)},
+		{ClearBlendMode, red, blue, ColorNRGBA(0, 0, 0, 0)},
+		{SrcBlendMode, red, blue, ColorNRGBA(0, 0, 255, 128)},
+		{DstBlendMode, red, blue, red},
+		{DstOverBlendMode, red, blue, red},
+		{SrcInBlendMode, red, blue, ColorNRGBA(0, 0, 255, 128)},
+		{SrcInBlendMode, empty, blue, ColorNRGBA(0, 0, 0, 0)},
+		{DstInBlendMode, red, blue, ColorNRGBA(255, 0, 0, 128)},
+		{SrcOutBlendMode, empty, blue, ColorNRGBA(0, 0, 255, 128)},
+		{DstOutBlendMode, red, blue, ColorNRGBA(255, 0, 0, 127)},
+		{SrcAtopBlendMode, red, blue, ColorNRGBA(127, 0, 128, 255)},
+		{XorBlendMode, red, blue, ColorNRGBA(255, 0, 0, 127)},
+		{PlusBlendMode, red, ColorBlue, ColorNRGBA(255, 0, 255, 255)},
+		{MultiplyBlendMode, red, gray, ColorNRGBA(128, 0, 0, 255)},
+		{ScreenBlendMode, red, gray, ColorNRGBA(255, 128, 128, 255)},
+		{DarkenBlendMode, red, gray, ColorNRGBA(128, 0, 0, 255)},
+		{LightenBlendMode, red, gray, ColorNRGBA(255, 128, 128, 255)},
+		{DifferenceBlendMode, red, gray, ColorNRGBA(127, 128, 128, 255)},
+		{LuminosityBlendMode, gray, red, ColorNRGBA(76, 76, 76, 255)},
+		{ColorBlendMode, gray, red, ColorNRGBA(255, 73, 73, 255)},
+		{MultiplyBlendMode, empty, gray, gray},
+	} {
+		got := color.NRGBAModel.Convert(tc.bm.Blend(tc.dst, tc.src)).(color.NRGBA)
+
+		if got != tc.want {
+			t.Fatalf("BlendMode(%d).Blend(%v, %v) = %v, want %v", tc.bm, tc.dst, tc.src, got, tc.want)
+		}
+	}
+}
+
+func TestDrawBlend(t *testing.T) {
+	dst := NewImage(4, 4, ColorRed)
+	src := NewImage(2, 2, ColorWhite)
+
+	DrawBlend(dst, IR(1, 1, 3, 3), src, ZP, DifferenceBlendMode)
+
+	if got, want := dst.RGBAAt(1, 1), ColorRGBA(0, 255, 255, 255); got != want {
+		t.Fatalf("dst.RGBAAt(1, 1) = %v, want %v", got, want)
+	}
+
+	if got, want := dst.RGBAAt(0, 0), ColorRGBA(255, 0, 0, 255); got != want {
+		t.Fatalf("dst.RGBAAt(0, 0) = %v, want %v", got, want)
+	}
+
+	DrawColorBlend(dst, dst.Bounds(), ColorWhite, DstOutBlendMode)
+
+	if got, want := dst.RGBAAt(3, 3).A, uint8(0); got != want {
+		t.Fatalf("dst.RGBAAt(3, 3).A = %d, want %d", got, want)
+	}
+}
+
+func TestMixBlend(t *testing.T) {
+	dst := NewImage(1, 1, ColorRGBA(128, 128, 128, 255))
+
+	MixBlend(dst, 0, 0, ColorRed, MultiplyBlendMode)
+
+	if got, want := dst.RGBAAt(0, 0), ColorRGBA(128, 0, 0, 255); got != want {
+		t.Fatalf("dst.RGBAAt(0, 0) = %v, want %v", got, want)
+	}
+}
+
+func TestTriangleDrawColorBlend(t *testing.T) {
+	dst := NewImage(8, 8, ColorRed)
+
+	tri := T(Vx(V(0, 0)), Vx(V(8, 0)), Vx(V(0, 8)))
+
+	if got := tri.DrawColorBlend(dst, ColorWhite, ScreenBlendMode); got == 0 {
+		t.Fatalf("expected rows to be drawn")
+	}
+
+	if got, want := dst.RGBAAt(1, 1), ColorRGBA(255, 255, 255, 255); got != want {
+		t.Fatalf("dst.RGBAAt(1, 1) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,213 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// ChromaticAdaptation is a chromatic adaptation transform, given by the
+// matrix that converts XYZ into the cone response domain.
+type ChromaticAdaptation [3][3]float64
+
+// Chromatic adaptation transforms
+var (
+	ChromaticAdaptationXYZScaling = ChromaticAdaptation{
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+	}
+
+	ChromaticAdaptationBradford = ChromaticAdaptation{
+		{0.8951, 0.2664, -0.1614},
+		{-0.7502, 1.7135, 0.0367},
+		{0.0389, -0.0685, 1.0296},
+	}
+
+	ChromaticAdaptationVonKries = ChromaticAdaptation{
+		{0.40024, 0.70760, -0.08081},
+		{-0.22630, 1.16532, 0.04570},
+		{0, 0, 0.91822},
+	}
+
+	ChromaticAdaptationCAT02 = ChromaticAdaptation{
+		{0.7328, 0.4296, -0.1624},
+		{-0.7036, 1.6975, 0.0061},
+		{0.0030, 0.0136, 0.9834},
+	}
+)
+
+// Matrix returns the matrix that adapts XYZ colors from the white point src
+// to the white point dst, such as XYZReference2.D50 and XYZReference2.D65.
+func (ca ChromaticAdaptation) Matrix(src, dst XYZ) [3][3]float64 {
+	s := mat3Apply(ca, src)
+	d := mat3Apply(ca, dst)
+
+	scale := [3][3]float64{
+		{d.X / s.X, 0, 0},
+		{0, d.Y / s.Y, 0},
+		{0, 0, d.Z / s.Z},
+	}
+
+	return mat3Mul(mat3Inverse(ca), mat3Mul(scale, ca))
+}
+
+// Adapt converts the color from the white point src to the white point dst
+// using the chromatic adaptation transform.
+func (xyz XYZ) Adapt(src, dst XYZ, ca ChromaticAdaptation) XYZ {
+	return mat3Apply(ca.Matrix(src, dst), xyz)
+}
+
+// CCT returns the correlated color temperature in Kelvin of the color,
+// using the approximation by McCamy. (accurate for about 2000-12500K)
+func (xyz XYZ) CCT() float64 {
+	s := xyz.X + xyz.Y + xyz.Z
+
+	x, y := xyz.X/s, xyz.Y/s
+
+	n := (x - 0.3320) / (0.1858 - y)
+
+	return 449*n*n*n + 3525*n*n + 6823.3*n + 5520.33
+}
+
+// KelvinToXYZ returns the XYZ (with Y of 100) of the color temperature in
+// Kelvin on the Planckian locus, using the approximation by Kim et al.
+//
+// The temperature is clamped to the range 1667-25000K.
+func KelvinToXYZ(kelvin float64) XYZ {
+	t := Clamp(kelvin, 1667, 25000)
+
+	t2, t3 := t*t, t*t*t
+
+	var x, y float64
+
+	if t <= 4000 {
+		x = -0.2661239e9/t3 - 0.2343589e6/t2 + 0.8776956e3/t + 0.179910
+	} else {
+		x = -3.0258469e9/t3 + 2.1070379e6/t2 + 0.2226347e3/t + 0.240390
+	}
+
+	x2, x3 := x*x, x*x*x
+
+	switch {
+	case t <= 2222:
+		y = -1.1063814*x3 - 1.34811020*x2 + 2.18555832*x - 0.20219683
+	case t <= 4000:
+		y = -0.9549476*x3 - 1.37418593*x2 + 2.09137015*x - 0.16748867
+	default:
+		y = 3.0817580*x3 - 5.87338670*x2 + 3.75112997*x - 0.37001483
+	}
+
+	return XYZ{
+		X: x * 100 / y,
+		Y: 100,
+		Z: (1 - x - y) * 100 / y,
+	}
+}
+
+// KelvinToColor returns the sRGB color of the color temperature in Kelvin,
+// scaled so that the largest component is at full intensity.
+func KelvinToColor(kelvin float64) color.NRGBA {
+	r, g, b := KelvinToXYZ(kelvin).linearRGB()
+
+	r, g, b = math.Max(r, 0), math.Max(g, 0), math.Max(b, 0)
+
+	m := math.Max(r, math.Max(g, b))
+
+	c := func(v float64) uint8 {
+		return uint8(math.Round(linearToSRGB(v/m) * 255))
+	}
+
+	return color.NRGBA{c(r), c(g), c(b), 255}
+}
+
+// WhiteBalance returns a copy of src with the colors adapted from the white
+// point from to the white point to, using the chromatic adaptation transform.
+//
+// For example, to correct a photo taken under tungsten light:
+//
+//	gfx.WhiteBalance(src, gfx.KelvinToXYZ(3200), gfx.XYZReference2.D65, gfx.ChromaticAdaptationBradford)
+func WhiteBalance(src image.Image, from, to XYZ, ca ChromaticAdaptation) *image.NRGBA {
+	m := ca.Matrix(from, to)
+
+	// Combine the conversion to XYZ, the adaptation and the conversion back to linear RGB.
+	var rgb [3][3]float64
+
+	for i := 0; i < 3; i++ {
+		var e [3]float64
+
+		e[i] = 1
+
+		r, g, b := mat3Apply(m, linearRGBToXYZ(e[0], e[1], e[2])).linearRGB()
+
+		rgb[0][i], rgb[1][i], rgb[2][i] = r, g, b
+	}
+
+	b := src.Bounds()
+	dst := image.NewNRGBA(b)
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			lr, lg, lb, a := linearNRGBA(src.At(x, y))
+
+			if a == 0 {
+				continue
+			}
+
+			v := mat3Apply(rgb, XYZ{lr, lg, lb})
+
+			dst.Set(x, y, colorFromLinearRGB(v.X, v.Y, v.Z, a))
+		}
+	}
+
+	return dst
+}
+
+// mat3Apply multiplies the 3x3 matrix m with the vector v.
+func mat3Apply(m [3][3]float64, v XYZ) XYZ {
+	return XYZ{
+		X: m[0][0]*v.X + m[0][1]*v.Y + m[0][2]*v.Z,
+		Y: m[1][0]*v.X + m[1][1]*v.Y + m[1][2]*v.Z,
+		Z: m[2][0]*v.X + m[2][1]*v.Y + m[2][2]*v.Z,
+	}
+}
+
+// mat3Mul multiplies the 3x3 matrices a and b.
+func mat3Mul(a, b [3][3]float64) [3][3]float64 {
+	var m [3][3]float64
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				m[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+
+	return m
+}
+
+// mat3Inverse returns the inverse of the 3x3 matrix m.
+func mat3Inverse(m [3][3]float64) [3][3]float64 {
+	det := m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+
+	return [3][3]float64{
+		{
+			(m[1][1]*m[2][2] - m[1][2]*m[2][1]) / det,
+			(m[0][2]*m[2][1] - m[0][1]*m[2][2]) / det,
+			(m[0][1]*m[1][2] - m[0][2]*m[1][1]) / det,
+		},
+		{
+			(m[1][2]*m[2][0] - m[1][0]*m[2][2]) / det,
+			(m[0][0]*m[2][2] - m[0][2]*m[2][0]) / det,
+			(m[0][2]*m[1][0] - m[0][0]*m[1][2]) / det,
+		},
+		{
+			(m[1][0]*m[2][1] - m[1][1]*m[2][0]) / det,
+			(m[0][1]*m[2][0] - m[0][0]*m[2][1]) / det,
+			(m[0][0]*m[1][1] - m[0][1]*m[1][0]) / det,
+		},
+	}
+}
@@ -0,0 +1,106 @@
+package gfx
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestChromaticAdaptationMatrix(t *testing.T) {
+	// Bradford D65 to D50 matrix by Bruce Lindbloom.
+	want := [3][3]float64{
+		{1.0478112, 0.0228866, -0.0501270},
+		{0.0295424, 0.9904844, -0.0170491},
+		{-0.0092345, 0.0150436, 0.7521316},
+	}
+
+	got := ChromaticAdaptationBradford.Matrix(XYZReference2.D65, XYZReference2.D50)
+
+	for i := range want {
+		for j := range want[i] {
+			if math.Abs(got[i][j]-want[i][j]) > 1e-6 {
+				t.Fatalf("m = %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+func TestXYZAdapt(t *testing.T) {
+	for _, ca := range []ChromaticAdaptation{
+		ChromaticAdaptationXYZScaling,
+		ChromaticAdaptationBradford,
+		ChromaticAdaptationVonKries,
+		ChromaticAdaptationCAT02,
+	} {
+		d50, d65 := XYZReference2.D50, XYZReference2.D65
+
+		if got := d50.Adapt(d50, d65, ca); math.Abs(got.X-d65.X) > 1e-9 || math.Abs(got.Z-d65.Z) > 1e-9 {
+			t.Fatalf("white = %+v, want %+v", got, d65)
+		}
+
+		c := XYZ{20, 30, 40}
+
+		if got := c.Adapt(d50, d65, ca).Adapt(d65, d50, ca); math.Abs(got.X-c.X) > 1e-9 || math.Abs(got.Y-c.Y) > 1e-9 {
+			t.Fatalf("round trip = %+v, want %+v", got, c)
+		}
+	}
+}
+
+func TestXYZCCT(t *testing.T) {
+	if got := XYZReference2.D65.CCT(); math.Abs(got-6504) > 5 {
+		t.Fatalf("D65.CCT() = %v, want about 6504", got)
+	}
+
+	for _, k := range []float64{2000, 3200, 5000, 6500, 9000} {
+		if got := KelvinToXYZ(k).CCT(); math.Abs(got-k)/k > 0.02 {
+			t.Fatalf("KelvinToXYZ(%v).CCT() = %v", k, got)
+		}
+	}
+}
+
+func TestKelvinToColor(t *testing.T) {
+	for _, tc := range []struct {
+		kelvin float64
+		check  func(c color.NRGBA) bool
+	}{
+		{1000, func(c color.NRGBA) bool { return c.R == 255 && c.B < 50 }},
+		{2700, func(c color.NRGBA) bool { return c.R == 255 && c.G > c.B }},
+		{6500, func(c color.NRGBA) bool { return c.R > 240 && c.G > 240 && c.B > 240 }},
+		{15000, func(c color.NRGBA) bool { return c.B == 255 && c.R < c.G }},
+	} {
+		if c := KelvinToColor(tc.kelvin); !tc.check(c) || c.A != 255 {
+			t.Fatalf("KelvinToColor(%v) = %v", tc.kelvin, c)
+		}
+	}
+}
+
+func TestWhiteBalance(t *testing.T) {
+	warm := KelvinToColor(3200)
+
+	src := NewImage(2, 1, ColorTransparent)
+
+	src.Set(0, 0, warm)
+
+	dst := WhiteBalance(src, KelvinToXYZ(3200), XYZReference2.D65, ChromaticAdaptationBradford)
+
+	if got, want := dst.Bounds(), src.Bounds(); got != want {
+		t.Fatalf("dst.Bounds() = %v, want %v", got, want)
+	}
+
+	// The color of the light is balanced to a neutral color.
+	c := dst.NRGBAAt(0, 0)
+
+	if math.Abs(float64(c.R)-float64(c.B)) > 6 || math.Abs(float64(c.R)-float64(c.G)) > 6 {
+		t.Fatalf("balanced = %v, want neutral", c)
+	}
+
+	if got := dst.NRGBAAt(1, 0); got.A != 0 {
+		t.Fatalf("transparent = %v", got)
+	}
+
+	same := WhiteBalance(src, XYZReference2.D65, XYZReference2.D65, ChromaticAdaptationCAT02)
+
+	if got, want := same.At(0, 0), color.NRGBAModel.Convert(warm); got != want {
+		t.Fatalf("same = %v, want %v", got, want)
+	}
+}
@@ -3,6 +3,9 @@ package gfx
 import "math"
 
 // CIELab represents a color in CIE-L*ab.
+//
+// Unlike CIELCh, OKLab and HSLuv it has no alpha, so it is always opaque when
+// used as a color.Color. Use CIELCh (and CIELChModel) for colors with alpha.
 type CIELab struct {
 	L float64
 	A float64
@@ -54,11 +57,60 @@ func (c1 CIELab) DeltaH(c2 CIELab) float64 {
 //
 //
 func (c1 CIELab) DeltaE(c2 CIELab) float64 {
-	return math.Sqrt(math.Pow(c1.L*1-c2.L*2, 2) +
-		math.Pow(c1.A*1-c2.A*2, 2) + math.Pow(c1.B*1-c2.B*2, 2),
+	return math.Sqrt(math.Pow(c1.L-c2.L, 2) +
+		math.Pow(c1.A-c2.A, 2) + math.Pow(c1.B-c2.B, 2),
 	)
 }
 
+// RGBA implements the color.Color interface, treating the color as an
+// opaque CIE-L*ab color using the D65/2° reference. Colors outside of
+// the sRGB gamut are mapped into the gamut using OKLab.Gamut.
+func (c CIELab) RGBA() (r, g, b, a uint32) {
+	lr, lg, lb := c.XYZ(XYZReference2.D65).linearRGB()
+
+	return colorFromLinearRGB(lr, lg, lb, 1).RGBA()
+}
+
+// XYZ converts from CIE-L*ab to XYZ.
+//
+// Reference-X, Y and Z refer to specific illuminants and observers.
+// Common reference values are available below in this same page.
+//
+// var_Y = ( CIE-L* + 16 ) / 116
+// var_X = CIE-a* / 500 + var_Y
+// var_Z = var_Y - CIE-b* / 200
+//
+// if ( var_Y^3  > 0.008856 ) var_Y = var_Y^3
+// else                       var_Y = ( var_Y - 16 / 116 ) / 7.787
+// if ( var_X^3  > 0.008856 ) var_X = var_X^3
+// else                       var_X = ( var_X - 16 / 116 ) / 7.787
+// if ( var_Z^3  > 0.008856 ) var_Z = var_Z^3
+// else                       var_Z = ( var_Z - 16 / 116 ) / 7.787
+//
+// X = var_X * Reference-X
+// Y = var_Y * Reference-Y
+// Z = var_Z * Reference-Z
+//
+func (c CIELab) XYZ(ref XYZ) XYZ {
+	Y := (c.L + 16) / 116
+	X := c.A/500 + Y
+	Z := Y - c.B/200
+
+	f := func(v float64) float64 {
+		if v3 := v * v * v; v3 > 0.008856 {
+			return v3
+		}
+
+		return (v - 16.0/116) / 7.787
+	}
+
+	return XYZ{
+		X: f(X) * ref.X,
+		Y: f(Y) * ref.Y,
+		Z: f(Z) * ref.Z,
+	}
+}
+
 // CIELab converts from XYZ to CIE-L*ab.
 //
 // Reference-X, Y and Z refer to specific illuminants and observers.
@@ -108,3 +160,167 @@ func (xyz XYZ) CIELab(ref XYZ) CIELab {
 		B: 200.0 * (Y - Z),
 	}
 }
+
+// CIE94Weights are the application dependent weights used by DeltaE94.
+type CIE94Weights struct {
+	KL float64
+	K1 float64
+	K2 float64
+}
+
+// Weights for DeltaE94
+var (
+	CIE94WeightsGraphicArts = CIE94Weights{KL: 1, K1: 0.045, K2: 0.015}
+	CIE94WeightsTextiles    = CIE94Weights{KL: 2, K1: 0.048, K2: 0.014}
+)
+
+// DeltaE94 calculates the CIE94 color difference of c2 from the reference
+// color c1, using the given application dependent weights.
+//
+// Delta L* = CIE-L*1 - CIE-L*2
+// Delta C* = C*1 - C*2
+// Delta H* = sqrt( Delta a* ^ 2 + Delta b* ^ 2 - Delta C* ^ 2 )
+//
+// Delta E94 = sqrt( ( Delta L* / KL ) ^ 2
+//                 + ( Delta C* / ( 1 + K1 * C*1 ) ) ^ 2
+//                 + ( Delta H* / ( 1 + K2 * C*1 ) ) ^ 2 )
+//
+func (c1 CIELab) DeltaE94(c2 CIELab, w CIE94Weights) float64 {
+	C1, C2 := math.Hypot(c1.A, c1.B), math.Hypot(c2.A, c2.B)
+
+	dL := c1.L - c2.L
+	dC := C1 - C2
+	dH2 := cieLabDeltaH2(c1, c2, dC)
+
+	sc := 1 + w.K1*C1
+	sh := 1 + w.K2*C1
+
+	return math.Sqrt(math.Pow(dL/w.KL, 2) + math.Pow(dC/sc, 2) + dH2/(sh*sh))
+}
+
+// DeltaE2000 calculates the CIEDE2000 color difference between c1 and c2.
+//
+// Based on "The CIEDE2000 Color-Difference Formula: Implementation Notes,
+// Supplementary Test Data, and Mathematical Observations" by Sharma, Wu and Dalal.
+func (c1 CIELab) DeltaE2000(c2 CIELab) float64 {
+	const pow25to7 = 6103515625 // 25^7
+
+	rad := math.Pi / 180
+
+	cBar := (math.Hypot(c1.A, c1.B) + math.Hypot(c2.A, c2.B)) / 2
+	cBar7 := math.Pow(cBar, 7)
+
+	g := 0.5 * (1 - math.Sqrt(cBar7/(cBar7+pow25to7)))
+
+	a1, a2 := (1+g)*c1.A, (1+g)*c2.A
+
+	C1, C2 := math.Hypot(a1, c1.B), math.Hypot(a2, c2.B)
+
+	hue := func(b, a float64) float64 {
+		if a == 0 && b == 0 {
+			return 0
+		}
+
+		h := math.Atan2(b, a) / rad
+
+		if h < 0 {
+			h += 360
+		}
+
+		return h
+	}
+
+	h1, h2 := hue(c1.B, a1), hue(c2.B, a2)
+
+	dL := c2.L - c1.L
+	dC := C2 - C1
+
+	var dh, hBar float64
+
+	switch {
+	case C1*C2 == 0:
+		dh, hBar = 0, h1+h2
+	case math.Abs(h2-h1) <= 180:
+		dh, hBar = h2-h1, (h1+h2)/2
+	default:
+		dh = h2 - h1 + 360
+
+		if h2-h1 > 180 {
+			dh = h2 - h1 - 360
+		}
+
+		hBar = (h1 + h2 - 360) / 2
+
+		if h1+h2 < 360 {
+			hBar = (h1 + h2 + 360) / 2
+		}
+	}
+
+	dH := 2 * math.Sqrt(C1*C2) * math.Sin(dh/2*rad)
+
+	lBar := (c1.L + c2.L) / 2
+	cBarP := (C1 + C2) / 2
+	cBarP7 := math.Pow(cBarP, 7)
+
+	t := 1 - 0.17*math.Cos((hBar-30)*rad) +
+		0.24*math.Cos(2*hBar*rad) +
+		0.32*math.Cos((3*hBar+6)*rad) -
+		0.20*math.Cos((4*hBar-63)*rad)
+
+	dTheta := 30 * math.Exp(-math.Pow((hBar-275)/25, 2))
+
+	rc := 2 * math.Sqrt(cBarP7/(cBarP7+pow25to7))
+
+	sl := 1 + 0.015*(lBar-50)*(lBar-50)/math.Sqrt(20+(lBar-50)*(lBar-50))
+	sc := 1 + 0.045*cBarP
+	sh := 1 + 0.015*cBarP*t
+
+	rt := -math.Sin(2*dTheta*rad) * rc
+
+	return math.Sqrt(math.Pow(dL/sl, 2) + math.Pow(dC/sc, 2) + math.Pow(dH/sh, 2) + rt*(dC/sc)*(dH/sh))
+}
+
+// DeltaCMC calculates the CMC l:c color difference of c2 from the reference
+// color c1, where l:c is commonly 2:1 for acceptability and 1:1 for perceptibility.
+//
+// Delta CMC = sqrt( ( Delta L* / ( l * SL ) ) ^ 2
+//                 + ( Delta C* / ( c * SC ) ) ^ 2
+//                 + ( Delta H* / SH ) ^ 2 )
+//
+func (c1 CIELab) DeltaCMC(c2 CIELab, l, c float64) float64 {
+	C1, C2 := math.Hypot(c1.A, c1.B), math.Hypot(c2.A, c2.B)
+
+	dL := c1.L - c2.L
+	dC := C1 - C2
+	dH2 := cieLabDeltaH2(c1, c2, dC)
+
+	h1 := math.Atan2(c1.B, c1.A) * 180 / math.Pi
+
+	if h1 < 0 {
+		h1 += 360
+	}
+
+	t := 0.36 + math.Abs(0.4*math.Cos((h1+35)*math.Pi/180))
+
+	if h1 >= 164 && h1 <= 345 {
+		t = 0.56 + math.Abs(0.2*math.Cos((h1+168)*math.Pi/180))
+	}
+
+	f := math.Sqrt(math.Pow(C1, 4) / (math.Pow(C1, 4) + 1900))
+
+	sl := 0.511
+
+	if c1.L >= 16 {
+		sl = 0.040975 * c1.L / (1 + 0.01765*c1.L)
+	}
+
+	sc := 0.0638*C1/(1+0.0131*C1) + 0.638
+	sh := sc * (f*t + 1 - f)
+
+	return math.Sqrt(math.Pow(dL/(l*sl), 2) + math.Pow(dC/(c*sc), 2) + dH2/(sh*sh))
+}
+
+// cieLabDeltaH2 returns the square of Delta H*, given Delta C*.
+func cieLabDeltaH2(c1, c2 CIELab, dC float64) float64 {
+	return math.Max(0, (c1.A-c2.A)*(c1.A-c2.A)+(c1.B-c2.B)*(c1.B-c2.B)-dC*dC)
+}
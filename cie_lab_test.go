@@ -1,5 +1,74 @@
 package gfx
 
+import (
+	"math"
+	"testing"
+)
+
+func TestCIELabDeltaE(t *testing.T) {
+	c1, c2 := CIELab{50, 10, 10}, CIELab{53, 14, 10}
+
+	if got, want := c1.DeltaE(c2), 5.0; got != want {
+		t.Fatalf("c1.DeltaE(c2) = %v, want %v", got, want)
+	}
+}
+
+func TestCIELabDeltaE94(t *testing.T) {
+	c1, c2 := CIELab{50, 2.6772, -79.7751}, CIELab{50, 0, -82.7485}
+
+	for _, tc := range []struct {
+		w    CIE94Weights
+		want float64
+	}{
+		{CIE94WeightsGraphicArts, 1.3950},
+		{CIE94WeightsTextiles, 1.4230},
+	} {
+		if got := c1.DeltaE94(c2, tc.w); math.Abs(got-tc.want) > 1e-4 {
+			t.Fatalf("c1.DeltaE94(c2, %+v) = %v, want %v", tc.w, got, tc.want)
+		}
+	}
+}
+
+func TestCIELabDeltaE2000(t *testing.T) {
+	// Test data from Sharma, Wu and Dalal.
+	for _, tc := range []struct {
+		c1, c2 CIELab
+		want   float64
+	}{
+		{CIELab{50, 2.6772, -79.7751}, CIELab{50, 0, -82.7485}, 2.0425},
+		{CIELab{50, -1, 2}, CIELab{50, 0, 0}, 2.3669},
+		{CIELab{50, 2.49, -0.001}, CIELab{50, -2.49, 0.0009}, 7.1792},
+		{CIELab{50, 2.49, -0.001}, CIELab{50, -2.49, 0.0011}, 7.2195},
+		{CIELab{50, 2.5, 0}, CIELab{73, 25, -18}, 27.1492},
+		{CIELab{60.2574, -34.0099, 36.2677}, CIELab{60.4626, -34.1751, 39.4387}, 1.2644},
+		{CIELab{2.0776, 0.0795, -1.1350}, CIELab{0.9033, -0.0636, -0.5514}, 0.9082},
+	} {
+		if got := tc.c1.DeltaE2000(tc.c2); math.Abs(got-tc.want) > 1e-4 {
+			t.Fatalf("%v.DeltaE2000(%v) = %v, want %v", tc.c1, tc.c2, got, tc.want)
+		}
+
+		if got := tc.c2.DeltaE2000(tc.c1); math.Abs(got-tc.want) > 1e-4 {
+			t.Fatalf("%v.DeltaE2000(%v) = %v, want %v", tc.c2, tc.c1, got, tc.want)
+		}
+	}
+}
+
+func TestCIELabDeltaCMC(t *testing.T) {
+	for _, tc := range []struct {
+		c1, c2 CIELab
+		l, c   float64
+		want   float64
+	}{
+		{CIELab{50, 2.6772, -79.7751}, CIELab{50, 0, -82.7485}, 2, 1, 1.7387},
+		{CIELab{50, 2.5, 0}, CIELab{73, 25, -18}, 2, 1, 37.9233},
+		{CIELab{50, 2.5, 0}, CIELab{73, 25, -18}, 1, 1, 42.1088},
+	} {
+		if got := tc.c1.DeltaCMC(tc.c2, tc.l, tc.c); math.Abs(got-tc.want) > 1e-4 {
+			t.Fatalf("%v.DeltaCMC(%v, %v, %v) = %v, want %v", tc.c1, tc.c2, tc.l, tc.c, got, tc.want)
+		}
+	}
+}
+
 func ExampleLab() {
 	var (
 		rgba   = ColorRGBA(255, 0, 0, 255)
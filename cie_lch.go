@@ -0,0 +1,87 @@
+package gfx
+
+import (
+	"image/color"
+	"math"
+)
+
+// CIELCh represents a color in CIE-L*CH°, the cylindrical form of CIE-L*ab.
+//   - L     [0,100]
+//   - C     [0,~150]
+//   - H     [0,360]
+//   - Alpha [0,1]
+//
+// The color.Color implementation and CIELChModel use the D65/2° reference.
+type CIELCh struct {
+	L     float64
+	C     float64
+	H     float64
+	Alpha float64
+}
+
+// CIELChModel is the color model for the CIE-L*CH° color space.
+var CIELChModel = color.ModelFunc(cieLChModel)
+
+func cieLChModel(c color.Color) color.Color {
+	if c, ok := c.(CIELCh); ok {
+		return c
+	}
+
+	r, g, b, a := linearNRGBA(c)
+
+	lch := linearRGBToXYZ(r, g, b).CIELab(XYZReference2.D65).CIELCh()
+	lch.Alpha = a
+
+	return lch
+}
+
+// CIELCh converts from XYZ to an opaque CIE-L*CH° color.
+func (xyz XYZ) CIELCh(ref XYZ) CIELCh {
+	return xyz.CIELab(ref).CIELCh()
+}
+
+// CIELCh converts from CIE-L*ab to an opaque CIE-L*CH° color.
+//
+// CIE-C* = sqrt( CIE-a* ^ 2 + CIE-b* ^ 2 )
+// CIE-H° = atan2( CIE-b*, CIE-a* ) in degrees
+func (c CIELab) CIELCh() CIELCh {
+	h := math.Atan2(c.B, c.A) * 180 / math.Pi
+
+	if h < 0 {
+		h += 360
+	}
+
+	return CIELCh{
+		L:     c.L,
+		C:     math.Hypot(c.A, c.B),
+		H:     h,
+		Alpha: 1,
+	}
+}
+
+// RGBA implements the color.Color interface. Colors outside of
+// the sRGB gamut are mapped into the gamut using OKLab.Gamut.
+func (c CIELCh) RGBA() (r, g, b, a uint32) {
+	lr, lg, lb := c.XYZ(XYZReference2.D65).linearRGB()
+
+	return colorFromLinearRGB(lr, lg, lb, c.Alpha).RGBA()
+}
+
+// CIELab converts from CIE-L*CH° to CIE-L*ab.
+//
+// CIE-a* = cos( CIE-H° ) * CIE-C*
+// CIE-b* = sin( CIE-H° ) * CIE-C*
+func (c CIELCh) CIELab() CIELab {
+	h := c.H * math.Pi / 180
+
+	return CIELab{
+		L: c.L,
+		A: c.C * math.Cos(h),
+		B: c.C * math.Sin(h),
+	}
+}
+
+// XYZ converts from CIE-L*CH° to XYZ.
+func (c CIELCh) XYZ(ref XYZ) XYZ {
+	return c.CIELab().XYZ(ref)
+}
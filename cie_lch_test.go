@@ -0,0 +1,41 @@
+package gfx
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestCIELCh(t *testing.T) {
+	lch := CIELChModel.Convert(ColorNRGBA(255, 0, 0, 255)).(CIELCh)
+
+	if math.Abs(lch.L-53.2329) > 1e-3 || math.Abs(lch.C-104.5755) > 1e-3 || math.Abs(lch.H-40.0002) > 1e-3 {
+		t.Fatalf("CIELCh = %+v", lch)
+	}
+
+	lab := lch.CIELab()
+
+	if got, want := lab.CIELCh(), lch; math.Abs(got.C-want.C) > 1e-9 || math.Abs(got.H-want.H) > 1e-9 {
+		t.Fatalf("lab.CIELCh() = %+v, want %+v", got, want)
+	}
+
+	if got, want := color.NRGBAModel.Convert(lab), ColorNRGBA(255, 0, 0, 255); got != want {
+		t.Fatalf("lab = %v, want %v", got, want)
+	}
+
+	if got := ColorToXYZ(ColorNRGBA(0, 0, 255, 255)).CIELCh(XYZReference2.D65); got.H < 0 || got.H >= 360 {
+		t.Fatalf("hue = %v, want value in range [0, 360)", got.H)
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		testRoundTrip(t, CIELChModel)
+	})
+
+	t.Run("Gamut", func(t *testing.T) {
+		c := color.NRGBAModel.Convert(CIELCh{L: 50, C: 150, H: 140, Alpha: 1}).(color.NRGBA)
+
+		if c.A != 255 || c.G < c.R || c.G < c.B {
+			t.Fatalf("c = %v, want green", c)
+		}
+	})
+}
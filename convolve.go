@@ -0,0 +1,446 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+)
+
+// EdgeMode determines how pixels outside of the image are sampled.
+type EdgeMode int
+
+const (
+	// ClampEdgeMode uses the closest pixel on the edge of the image.
+	ClampEdgeMode EdgeMode = iota
+
+	// WrapEdgeMode wraps around to the opposite edge of the image.
+	WrapEdgeMode
+
+	// MirrorEdgeMode mirrors the image at its edges.
+	MirrorEdgeMode
+)
+
+// Apply maps the coordinate i into the range [0, n) based on the edge mode.
+func (em EdgeMode) Apply(i, n int) int {
+	if i >= 0 && i < n {
+		return i
+	}
+
+	switch em {
+	case WrapEdgeMode:
+		if i %= n; i < 0 {
+			i += n
+		}
+
+		return i
+	case MirrorEdgeMode:
+		if n == 1 {
+			return 0
+		}
+
+		p := 2 * (n - 1)
+
+		if i %= p; i < 0 {
+			i += p
+		}
+
+		if i >= n {
+			i = p - i
+		}
+
+		return i
+	default:
+		return IntClamp(i, 0, n-1)
+	}
+}
+
+// Kernel is a convolution kernel of Width×Height values, centered
+// on the pixel being processed.
+type Kernel struct {
+	Width  int
+	Height int
+	Values []float64
+}
+
+// NewKernel creates a new Kernel with the given size and values (row by row).
+func NewKernel(w, h int, values ...float64) Kernel {
+	if len(values) != w*h {
+		panic(Errorf("gfx: kernel of size %dx%d needs %d values, got %d", w, h, w*h, len(values)))
+	}
+
+	return Kernel{Width: w, Height: h, Values: values}
+}
+
+// At returns the value at x, y in the kernel.
+func (k Kernel) At(x, y int) float64 {
+	return k.Values[y*k.Width+x]
+}
+
+// Sum returns the sum of the values in the kernel.
+func (k Kernel) Sum() float64 {
+	var sum float64
+
+	for _, v := range k.Values {
+		sum += v
+	}
+
+	return sum
+}
+
+// Normalized returns a copy of the kernel where the values add up to 1.
+// (Kernels that add up to 0 are returned as is)
+func (k Kernel) Normalized() Kernel {
+	sum := k.Sum()
+
+	values := make([]float64, len(k.Values))
+
+	for i, v := range k.Values {
+		if sum != 0 {
+			v /= sum
+		}
+
+		values[i] = v
+	}
+
+	return Kernel{Width: k.Width, Height: k.Height, Values: values}
+}
+
+// Kernels used by the filters.
+var (
+	KernelSharpen = NewKernel(3, 3,
+		0, -1, 0,
+		-1, 5, -1,
+		0, -1, 0,
+	)
+
+	KernelEmboss = NewKernel(3, 3,
+		-2, -1, 0,
+		-1, 1, 1,
+		0, 1, 2,
+	)
+)
+
+// imageBuffer holds the premultiplied color components of an image, in the range 0-1.
+type imageBuffer struct {
+	b    image.Rectangle
+	w, h int
+	pix  [][4]float64
+}
+
+func newImageBuffer(src image.Image) *imageBuffer {
+	b := src.Bounds()
+
+	ib := &imageBuffer{b: b, w: b.Dx(), h: b.Dy(), pix: make([][4]float64, b.Dx()*b.Dy())}
+
+	for y := 0; y < ib.h; y++ {
+		for x := 0; x < ib.w; x++ {
+			r, g, b, a := src.At(ib.b.Min.X+x, ib.b.Min.Y+y).RGBA()
+
+			ib.pix[y*ib.w+x] = [4]float64{
+				float64(r) / 0xFFFF,
+				float64(g) / 0xFFFF,
+				float64(b) / 0xFFFF,
+				float64(a) / 0xFFFF,
+			}
+		}
+	}
+
+	return ib
+}
+
+// at returns the components at x, y (relative to the bounds) using the edge mode.
+func (ib *imageBuffer) at(x, y int, em EdgeMode) [4]float64 {
+	return ib.pix[em.Apply(y, ib.h)*ib.w+em.Apply(x, ib.w)]
+}
+
+// convolve returns a new buffer convolved with the kernel.
+func (ib *imageBuffer) convolve(k Kernel, em EdgeMode) *imageBuffer {
+	out := &imageBuffer{b: ib.b, w: ib.w, h: ib.h, pix: make([][4]float64, len(ib.pix))}
+
+	cx, cy := k.Width/2, k.Height/2
+
+	for y := 0; y < ib.h; y++ {
+		for x := 0; x < ib.w; x++ {
+			var sum [4]float64
+
+			for ky := 0; ky < k.Height; ky++ {
+				for kx := 0; kx < k.Width; kx++ {
+					v := k.Values[ky*k.Width+kx]
+
+					if v == 0 {
+						continue
+					}
+
+					p := ib.at(x+kx-cx, y+ky-cy, em)
+
+					sum[0] += p[0] * v
+					sum[1] += p[1] * v
+					sum[2] += p[2] * v
+					sum[3] += p[3] * v
+				}
+			}
+
+			out.pix[y*ib.w+x] = sum
+		}
+	}
+
+	return out
+}
+
+// rgba converts the buffer into an RGBA image, clamping the components.
+func (ib *imageBuffer) rgba() *image.RGBA {
+	dst := NewRGBA(ib.b)
+
+	for y := 0; y < ib.h; y++ {
+		for x := 0; x < ib.w; x++ {
+			p := ib.pix[y*ib.w+x]
+
+			a := Clamp(p[3], 0, 1)
+
+			c := func(v float64) uint8 {
+				return uint8(math.Round(Clamp(v, 0, a) * 255))
+			}
+
+			dst.SetRGBA(ib.b.Min.X+x, ib.b.Min.Y+y, color.RGBA{c(p[0]), c(p[1]), c(p[2]), c(a)})
+		}
+	}
+
+	return dst
+}
+
+// Convolve the src image with the kernel, in premultiplied alpha.
+func Convolve(src image.Image, k Kernel, em EdgeMode) *image.RGBA {
+	return newImageBuffer(src).convolve(k, em).rgba()
+}
+
+// ConvolveSeparable convolves the src image with the horizontal kernel h
+// followed by the vertical kernel v, in premultiplied alpha.
+func ConvolveSeparable(src image.Image, h, v []float64, em EdgeMode) *image.RGBA {
+	return newImageBuffer(src).
+		convolve(NewKernel(len(h), 1, h...), em).
+		convolve(NewKernel(1, len(v), v...), em).
+		rgba()
+}
+
+// GaussianKernel returns the normalized one dimensional Gaussian kernel
+// for the standard deviation sigma, with a radius of 3 sigma.
+func GaussianKernel(sigma float64) []float64 {
+	r := int(math.Ceil(sigma * 3))
+
+	if sigma <= 0 || r < 1 {
+		return []float64{1}
+	}
+
+	k := make([]float64, 2*r+1)
+
+	var sum float64
+
+	for i := range k {
+		x := float64(i - r)
+
+		k[i] = math.Exp(-(x * x) / (2 * sigma * sigma))
+		sum += k[i]
+	}
+
+	for i := range k {
+		k[i] /= sum
+	}
+
+	return k
+}
+
+// GaussianBlur blurs the src image using a separable Gaussian kernel.
+func GaussianBlur(src image.Image, sigma float64, em EdgeMode) *image.RGBA {
+	k := GaussianKernel(sigma)
+
+	return ConvolveSeparable(src, k, k, em)
+}
+
+// BoxBlur blurs the src image by averaging the pixels within the radius.
+func BoxBlur(src image.Image, radius int, em EdgeMode) *image.RGBA {
+	k := make([]float64, 2*IntMax(0, radius)+1)
+
+	for i := range k {
+		k[i] = 1 / float64(len(k))
+	}
+
+	return ConvolveSeparable(src, k, k, em)
+}
+
+// Sharpen the src image using KernelSharpen.
+func Sharpen(src image.Image, em EdgeMode) *image.RGBA {
+	return Convolve(src, KernelSharpen, em)
+}
+
+// UnsharpMask sharpens the src image by adding the difference between
+// the image and a Gaussian blurred version of it, multiplied by amount.
+//
+// Differences smaller than the threshold (range 0-1) are ignored.
+func UnsharpMask(src image.Image, sigma, amount, threshold float64, em EdgeMode) *image.RGBA {
+	ib := newImageBuffer(src)
+	k := GaussianKernel(sigma)
+
+	blurred := ib.convolve(NewKernel(len(k), 1, k...), em).convolve(NewKernel(1, len(k), k...), em)
+
+	out := &imageBuffer{b: ib.b, w: ib.w, h: ib.h, pix: make([][4]float64, len(ib.pix))}
+
+	for i, p := range ib.pix {
+		q := blurred.pix[i]
+
+		for j := 0; j < 3; j++ {
+			if d := p[j] - q[j]; math.Abs(d) >= threshold {
+				p[j] += d * amount
+			}
+		}
+
+		out.pix[i] = p
+	}
+
+	return out.rgba()
+}
+
+// Emboss the src image using KernelEmboss, keeping the alpha of the src image.
+func Emboss(src image.Image, em EdgeMode) *image.NRGBA {
+	ib := newImageBuffer(src).unpremultiplied()
+
+	out := ib.convolve(KernelEmboss, em)
+
+	dst := NewNRGBA(ib.b)
+
+	for i, p := range out.pix {
+		c := func(v float64) uint8 {
+			return uint8(math.Round(Clamp(v, 0, 1) * 255))
+		}
+
+		dst.SetNRGBA(ib.b.Min.X+i%ib.w, ib.b.Min.Y+i/ib.w, color.NRGBA{c(p[0]), c(p[1]), c(p[2]),
+			uint8(math.Round(ib.pix[i][3] * 255)),
+		})
+	}
+
+	return dst
+}
+
+// unpremultiplied returns a copy of the buffer with unpremultiplied color components.
+func (ib *imageBuffer) unpremultiplied() *imageBuffer {
+	out := &imageBuffer{b: ib.b, w: ib.w, h: ib.h, pix: make([][4]float64, len(ib.pix))}
+
+	for i, p := range ib.pix {
+		if a := p[3]; a > 0 {
+			p[0], p[1], p[2] = p[0]/a, p[1]/a, p[2]/a
+		}
+
+		out.pix[i] = p
+	}
+
+	return out
+}
+
+// EdgeOperator is a pair of kernels used to estimate the horizontal
+// and vertical gradients of an image.
+type EdgeOperator struct {
+	X Kernel
+	Y Kernel
+}
+
+// Edge detection operators.
+var (
+	SobelEdgeOperator = EdgeOperator{
+		X: NewKernel(3, 3, -1, 0, 1, -2, 0, 2, -1, 0, 1),
+		Y: NewKernel(3, 3, -1, -2, -1, 0, 0, 0, 1, 2, 1),
+	}
+
+	PrewittEdgeOperator = EdgeOperator{
+		X: NewKernel(3, 3, -1, 0, 1, -1, 0, 1, -1, 0, 1),
+		Y: NewKernel(3, 3, -1, -1, -1, 0, 0, 0, 1, 1, 1),
+	}
+
+	ScharrEdgeOperator = EdgeOperator{
+		X: NewKernel(3, 3, -3, 0, 3, -10, 0, 10, -3, 0, 3),
+		Y: NewKernel(3, 3, -3, -10, -3, 0, 0, 0, 3, 10, 3),
+	}
+)
+
+// DetectEdges returns the gradient magnitude of the luminance of the src
+// image as a grayscale image, keeping the alpha of the src image.
+//
+// The magnitude is normalized so that the largest possible gradient is white.
+func DetectEdges(src image.Image, op EdgeOperator, em EdgeMode) *image.NRGBA {
+	ib := newImageBuffer(src)
+
+	lum := &imageBuffer{b: ib.b, w: ib.w, h: ib.h, pix: make([][4]float64, len(ib.pix))}
+
+	for i, p := range ib.pix {
+		l := 0.2126*p[0] + 0.7152*p[1] + 0.0722*p[2]
+
+		lum.pix[i] = [4]float64{l, l, l, p[3]}
+	}
+
+	gx, gy := lum.convolve(op.X, em), lum.convolve(op.Y, em)
+
+	// The largest gradient is the sum of the positive values of the X kernel.
+	var max float64
+
+	for _, v := range op.X.Values {
+		max += math.Max(0, v)
+	}
+
+	dst := NewNRGBA(ib.b)
+
+	for i := range ib.pix {
+		m := math.Hypot(gx.pix[i][0], gy.pix[i][0]) / (max * math.Sqrt2)
+
+		v := uint8(math.Round(Clamp(m, 0, 1) * 255))
+
+		dst.SetNRGBA(ib.b.Min.X+i%ib.w, ib.b.Min.Y+i/ib.w, color.NRGBA{v, v, v, uint8(math.Round(ib.pix[i][3] * 255))})
+	}
+
+	return dst
+}
+
+// Median replaces each pixel with the per channel median of the pixels
+// within the radius.
+func Median(src image.Image, radius int, em EdgeMode) *image.NRGBA {
+	ib := newImageBuffer(src).unpremultiplied()
+
+	dst := NewNRGBA(ib.b)
+
+	radius = IntMax(0, radius)
+	n := (2*radius + 1) * (2*radius + 1)
+
+	var values [4][]float64
+
+	for c := range values {
+		values[c] = make([]float64, n)
+	}
+
+	for y := 0; y < ib.h; y++ {
+		for x := 0; x < ib.w; x++ {
+			i := 0
+
+			for dy := -radius; dy <= radius; dy++ {
+				for dx := -radius; dx <= radius; dx++ {
+					p := ib.at(x+dx, y+dy, em)
+
+					for c := range values {
+						values[c][i] = p[c]
+					}
+
+					i++
+				}
+			}
+
+			var out [4]uint8
+
+			for c := range values {
+				sort.Float64s(values[c])
+
+				out[c] = uint8(math.Round(values[c][n/2] * 255))
+			}
+
+			dst.SetNRGBA(ib.b.Min.X+x, ib.b.Min.Y+y, color.NRGBA{out[0], out[1], out[2], out[3]})
+		}
+	}
+
+	return dst
+}
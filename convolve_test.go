@@ -0,0 +1,93 @@
+package gfx
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestEdgeModeApply(t *testing.T) {
+	for _, tc := range []struct {
+		em   EdgeMode
+		i    int
+		want int
+	}{
+		{ClampEdgeMode, -2, 0},
+		{ClampEdgeMode, 6, 4},
+		{WrapEdgeMode, -1, 4},
+		{WrapEdgeMode, 6, 1},
+		{MirrorEdgeMode, -1, 1},
+		{MirrorEdgeMode, 5, 3},
+		{MirrorEdgeMode, 3, 3},
+	} {
+		if got := tc.em.Apply(tc.i, 5); got != tc.want {
+			t.Fatalf("%d.Apply(%d, 5) = %d, want %d", tc.em, tc.i, got, tc.want)
+		}
+	}
+}
+
+func TestFilters(t *testing.T) {
+	m := NewNRGBA(IR(0, 0, 5, 5))
+
+	DrawColor(m, m.Bounds(), ColorNRGBA(100, 150, 200, 255))
+
+	for _, tc := range []struct {
+		name string
+		fn   func() color.Color
+	}{
+		{"GaussianBlur", func() color.Color { return GaussianBlur(m, 1.5, ClampEdgeMode).At(2, 2) }},
+		{"BoxBlur", func() color.Color { return BoxBlur(m, 2, WrapEdgeMode).At(0, 0) }},
+		{"Sharpen", func() color.Color { return Sharpen(m, MirrorEdgeMode).At(4, 4) }},
+		{"UnsharpMask", func() color.Color { return UnsharpMask(m, 1, 1, 0, ClampEdgeMode).At(1, 3) }},
+		{"Emboss", func() color.Color { return Emboss(m, ClampEdgeMode).At(2, 2) }},
+		{"Median", func() color.Color { return Median(m, 1, ClampEdgeMode).At(0, 4) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := color.NRGBAModel.Convert(tc.fn()), ColorNRGBA(100, 150, 200, 255); got != want {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGaussianBlurAlpha(t *testing.T) {
+	m := NewNRGBA(IR(0, 0, 3, 1))
+
+	m.SetNRGBA(1, 0, ColorNRGBA(255, 0, 0, 255))
+
+	got := color.NRGBAModel.Convert(BoxBlur(m, 1, ClampEdgeMode).At(0, 0)).(color.NRGBA)
+
+	if want := ColorNRGBA(255, 0, 0, 85); got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestDetectEdges(t *testing.T) {
+	m := NewNRGBA(IR(0, 0, 4, 3))
+
+	DrawColor(m, IR(2, 0, 4, 3), ColorWhite)
+	DrawColor(m, IR(0, 0, 2, 3), ColorBlack)
+
+	for _, op := range []EdgeOperator{SobelEdgeOperator, PrewittEdgeOperator, ScharrEdgeOperator} {
+		e := DetectEdges(m, op, ClampEdgeMode)
+
+		if got := e.NRGBAAt(0, 1).R; got != 0 {
+			t.Fatalf("e.NRGBAAt(0, 1).R = %d, want 0", got)
+		}
+
+		if got := e.NRGBAAt(1, 1).R; got < 128 {
+			t.Fatalf("e.NRGBAAt(1, 1).R = %d, want >= 128", got)
+		}
+	}
+}
+
+func TestMedian(t *testing.T) {
+	m := NewNRGBA(IR(0, 0, 3, 3))
+
+	DrawColor(m, m.Bounds(), ColorWhite)
+
+	m.SetNRGBA(1, 1, ColorBlack)
+
+	if got, want := Median(m, 1, ClampEdgeMode).NRGBAAt(1, 1), ColorWhite; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,318 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// CVD is a type of color vision deficiency (color blindness).
+type CVD int
+
+const (
+	// ProtanopiaCVD is the absence of the long wavelength (red) cones.
+	ProtanopiaCVD CVD = iota
+
+	// DeuteranopiaCVD is the absence of the medium wavelength (green) cones.
+	DeuteranopiaCVD
+
+	// TritanopiaCVD is the absence of the short wavelength (blue) cones.
+	TritanopiaCVD
+
+	// AchromatopsiaCVD is the absence of color vision, where only the luminance is perceived.
+	AchromatopsiaCVD
+)
+
+// CVDMethod is a method used to simulate color vision deficiencies.
+type CVDMethod int
+
+const (
+	// MachadoCVDMethod is the simulation by Machado, Oliveira and Fernandes (2009).
+	MachadoCVDMethod CVDMethod = iota
+
+	// BrettelCVDMethod is the simulation by Brettel, Viénot and Mollon (1997).
+	BrettelCVDMethod
+
+	// VienotCVDMethod is the simulation by Viénot, Brettel and Mollon (1999).
+	// It is a faster approximation of BrettelCVDMethod, which is used for tritanopia.
+	VienotCVDMethod
+)
+
+// CVD simulation matrices in linear RGB, as computed by DaltonLens.
+// https://daltonlens.org/opensource-cvd-simulation/
+var (
+	cvdMachado = [3][3][3]float64{
+		ProtanopiaCVD: {
+			{0.152286, 1.052583, -0.204868},
+			{0.114503, 0.786281, 0.099216},
+			{-0.003882, -0.048116, 1.051998},
+		},
+		DeuteranopiaCVD: {
+			{0.367322, 0.860646, -0.227968},
+			{0.280085, 0.672501, 0.047413},
+			{-0.011820, 0.042940, 0.968881},
+		},
+		TritanopiaCVD: {
+			{1.255528, -0.076749, -0.178779},
+			{-0.078411, 0.930809, 0.147602},
+			{0.004733, 0.691367, 0.303900},
+		},
+	}
+
+	cvdVienot = [2][3][3]float64{
+		ProtanopiaCVD: {
+			{0.11238, 0.88762, 0},
+			{0.11238, 0.88762, 0},
+			{0.00401, -0.00401, 1},
+		},
+		DeuteranopiaCVD: {
+			{0.29275, 0.70725, 0},
+			{0.29275, 0.70725, 0},
+			{-0.02234, 0.02234, 1},
+		},
+	}
+
+	// cvdBrettel holds the matrices for each of the two half-planes, and the
+	// normal of the plane separating them.
+	cvdBrettel = [3]struct {
+		m      [2][3][3]float64
+		normal XYZ
+	}{
+		ProtanopiaCVD: {
+			[2][3][3]float64{
+				{{0.14980, 1.19548, -0.34528}, {0.10764, 0.84864, 0.04372}, {0.00384, -0.00540, 1.00156}},
+				{{0.14570, 1.16172, -0.30742}, {0.10816, 0.85291, 0.03892}, {0.00386, -0.00524, 1.00139}},
+			},
+			XYZ{0.00048, 0.00393, -0.00441},
+		},
+		DeuteranopiaCVD: {
+			[2][3][3]float64{
+				{{0.36477, 0.86381, -0.22858}, {0.26294, 0.64245, 0.09462}, {-0.02006, 0.02728, 0.99278}},
+				{{0.37298, 0.88166, -0.25464}, {0.25954, 0.63506, 0.10540}, {-0.01980, 0.02784, 0.99196}},
+			},
+			XYZ{-0.00281, -0.00611, 0.00892},
+		},
+		TritanopiaCVD: {
+			[2][3][3]float64{
+				{{1.01277, 0.13548, -0.14826}, {-0.01243, 0.86812, 0.14431}, {0.07589, 0.80500, 0.11911}},
+				{{0.93678, 0.18979, -0.12657}, {0.06154, 0.81526, 0.12320}, {-0.37562, 1.12767, 0.24796}},
+			},
+			XYZ{0.03901, -0.02788, -0.01113},
+		},
+	}
+)
+
+// CVDSimulator simulates how colors are perceived with a color vision deficiency.
+//
+// Severity is in the range [0, 1], where 1 is the complete absence of the
+// cones (dichromacy) and a zero Severity is treated as 1. A Severity below 1
+// simulates anomalous trichromacy (such as protanomaly), by interpolating
+// between the original and the simulated color (or the Machado matrix).
+type CVDSimulator struct {
+	Deficiency CVD
+	Method     CVDMethod
+	Severity   float64
+}
+
+// Color returns the color c as perceived with the color vision deficiency.
+func (s CVDSimulator) Color(c color.Color) color.NRGBA {
+	r, g, b, a := linearNRGBA(c)
+
+	r, g, b = s.linearRGB(r, g, b)
+
+	return linearNRGBAColor(r, g, b, a)
+}
+
+// Image returns a copy of src as perceived with the color vision deficiency.
+func (s CVDSimulator) Image(src image.Image) *image.NRGBA {
+	return s.apply(src, s.linearRGB)
+}
+
+// DaltonizeColor returns the color c corrected for the color vision
+// deficiency, by shifting the colors that are lost in the simulation
+// towards colors that are perceived. (Fidaner, Lin and Ozguven)
+//
+// Colors are unchanged for AchromatopsiaCVD, since no hue is perceived.
+func (s CVDSimulator) DaltonizeColor(c color.Color) color.NRGBA {
+	r, g, b, a := linearNRGBA(c)
+
+	r, g, b = s.daltonize(r, g, b)
+
+	return linearNRGBAColor(r, g, b, a)
+}
+
+// Daltonize returns a copy of src corrected for the color vision deficiency.
+// See DaltonizeColor.
+func (s CVDSimulator) Daltonize(src image.Image) *image.NRGBA {
+	return s.apply(src, s.daltonize)
+}
+
+// linearRGB simulates the color vision deficiency on linear R, G and B.
+func (s CVDSimulator) linearRGB(r, g, b float64) (float64, float64, float64) {
+	severity := s.Severity
+
+	if severity <= 0 || severity > 1 {
+		severity = 1
+	}
+
+	v := XYZ{r, g, b}
+
+	var sim XYZ
+
+	switch {
+	case s.Deficiency == AchromatopsiaCVD:
+		y := linearRGBToXYZ(r, g, b).Y / 100
+
+		sim = XYZ{y, y, y}
+	case s.Method == BrettelCVDMethod || s.Method == VienotCVDMethod && s.Deficiency == TritanopiaCVD:
+		p := cvdBrettel[s.Deficiency]
+
+		if v.X*p.normal.X+v.Y*p.normal.Y+v.Z*p.normal.Z >= 0 {
+			sim = mat3Apply(p.m[0], v)
+		} else {
+			sim = mat3Apply(p.m[1], v)
+		}
+	case s.Method == VienotCVDMethod:
+		sim = mat3Apply(cvdVienot[s.Deficiency], v)
+	default:
+		m := cvdMachado[s.Deficiency]
+
+		// Interpolate the matrix from the identity, as an approximation of
+		// the matrices for each severity in the paper.
+		for i := range m {
+			for j := range m[i] {
+				id := 0.0
+
+				if i == j {
+					id = 1
+				}
+
+				m[i][j] = Lerp(id, m[i][j], severity)
+			}
+		}
+
+		sim = mat3Apply(m, v)
+		severity = 1
+	}
+
+	return Lerp(r, sim.X, severity), Lerp(g, sim.Y, severity), Lerp(b, sim.Z, severity)
+}
+
+// daltonize corrects linear R, G and B for the color vision deficiency.
+func (s CVDSimulator) daltonize(r, g, b float64) (float64, float64, float64) {
+	if s.Deficiency == AchromatopsiaCVD {
+		return r, g, b
+	}
+
+	sr, sg, sb := s.linearRGB(r, g, b)
+
+	er, eg, eb := r-sr, g-sg, b-sb
+
+	if s.Deficiency == TritanopiaCVD {
+		// Shift the lost blue information into red and green.
+		return r + 0.7*eb, g + 0.7*eb, b
+	}
+
+	// Shift the lost red and green information into green and blue.
+	return r, g + 0.7*er + eg, b + 0.7*er + eb
+}
+
+// apply returns a copy of src with fn applied to the linear R, G and B of each pixel.
+func (s CVDSimulator) apply(src image.Image, fn func(r, g, b float64) (float64, float64, float64)) *image.NRGBA {
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(bounds)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := linearNRGBA(src.At(x, y))
+
+			if a == 0 {
+				continue
+			}
+
+			r, g, b = fn(r, g, b)
+
+			dst.SetNRGBA(x, y, linearNRGBAColor(r, g, b, a))
+		}
+	}
+
+	return dst
+}
+
+// linearNRGBAColor converts linear R, G and B, and the alpha into a color.NRGBA,
+// clipping the components to the sRGB gamut.
+func linearNRGBAColor(r, g, b, a float64) color.NRGBA {
+	c := func(v float64) uint8 {
+		return uint8(math.Round(linearToSRGB(Clamp(v, 0, 1)) * 0xFF))
+	}
+
+	return color.NRGBA{c(r), c(g), c(b), uint8(math.Round(Clamp(a, 0, 1) * 0xFF))}
+}
+
+// RelativeLuminance returns the relative luminance (range [0, 1]) of the
+// color as defined by WCAG 2.x, ignoring the alpha.
+// https://www.w3.org/TR/WCAG21/#dfn-relative-luminance
+func RelativeLuminance(c color.Color) float64 {
+	r, g, b, _ := linearNRGBA(c)
+
+	return 0.2126*r + 0.7152*g + 0.0722*b
+}
+
+// ContrastRatio returns the WCAG 2.x contrast ratio (range [1, 21]) between
+// the two colors. WCAG level AA requires a ratio of at least 4.5 for normal
+// text and 3 for large text, and level AAA requires 7 and 4.5 respectively.
+// https://www.w3.org/TR/WCAG21/#dfn-contrast-ratio
+func ContrastRatio(c1, c2 color.Color) float64 {
+	l1, l2 := RelativeLuminance(c1), RelativeLuminance(c2)
+
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
+// CVDPair is a pair of palette colors I and J (where I < J), with the
+// CIEDE2000 color difference between the original and the simulated colors.
+type CVDPair struct {
+	I, J      int
+	DeltaE    float64
+	SimDeltaE float64
+}
+
+// CVDReport returns the pairs of colors in the palette that become
+// indistinguishable with the color vision deficiency, where the CIEDE2000
+// color difference of the simulated colors is below the threshold, but not
+// of the original colors. (A difference of about 2.3 is just noticeable, but
+// colors in charts and user interfaces need a larger difference, such as 10)
+//
+// Pairs are ordered by I and J.
+func (p Palette) CVDReport(s CVDSimulator, threshold float64) []CVDPair {
+	lab := func(r, g, b float64) CIELab {
+		return linearRGBToXYZ(r, g, b).CIELab(XYZReference2.D65)
+	}
+
+	orig := make([]CIELab, len(p))
+	sim := make([]CIELab, len(p))
+
+	for i, c := range p {
+		r, g, b, _ := linearNRGBA(c)
+
+		orig[i] = lab(r, g, b)
+		sim[i] = lab(s.linearRGB(r, g, b))
+	}
+
+	var pairs []CVDPair
+
+	for i := range p {
+		for j := i + 1; j < len(p); j++ {
+			d, sd := orig[i].DeltaE2000(orig[j]), sim[i].DeltaE2000(sim[j])
+
+			if sd < threshold && d >= threshold {
+				pairs = append(pairs, CVDPair{I: i, J: j, DeltaE: d, SimDeltaE: sd})
+			}
+		}
+	}
+
+	return pairs
+}
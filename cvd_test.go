@@ -0,0 +1,159 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestCVDSimulatorColor(t *testing.T) {
+	deficiencies := []CVD{ProtanopiaCVD, DeuteranopiaCVD, TritanopiaCVD, AchromatopsiaCVD}
+	methods := []CVDMethod{MachadoCVDMethod, BrettelCVDMethod, VienotCVDMethod}
+
+	for _, d := range deficiencies {
+		for _, m := range methods {
+			s := CVDSimulator{Deficiency: d, Method: m}
+
+			for _, c := range []color.NRGBA{
+				{0, 0, 0, 255}, {255, 255, 255, 255}, {128, 128, 128, 128},
+			} {
+				if got := s.Color(c); absDiff(got.R, c.R) > 1 || absDiff(got.G, c.G) > 1 || absDiff(got.B, c.B) > 1 || got.A != c.A {
+					t.Fatalf("%+v.Color(%v) = %v, want %v", s, c, got, c)
+				}
+			}
+		}
+	}
+
+	t.Run("Achromatopsia", func(t *testing.T) {
+		got := CVDSimulator{Deficiency: AchromatopsiaCVD}.Color(ColorNRGBA(0, 255, 0, 255))
+
+		if got.R != got.G || got.G != got.B {
+			t.Fatalf("got %v, want gray", got)
+		}
+
+		if l := RelativeLuminance(got); math.Abs(l-0.7152) > 0.01 {
+			t.Fatalf("RelativeLuminance = %v, want 0.7152", l)
+		}
+	})
+
+	t.Run("Methods", func(t *testing.T) {
+		red := ColorNRGBA(255, 0, 0, 255)
+
+		for _, d := range []CVD{ProtanopiaCVD, DeuteranopiaCVD} {
+			brettel := CVDSimulator{Deficiency: d, Method: BrettelCVDMethod}.Color(red)
+
+			for _, m := range []CVDMethod{MachadoCVDMethod, VienotCVDMethod} {
+				got := CVDSimulator{Deficiency: d, Method: m}.Color(red)
+
+				if e := ColorToXYZ(got).CIELab(XYZReference2.D65).DeltaE2000(ColorToXYZ(brettel).CIELab(XYZReference2.D65)); e > 8 {
+					t.Fatalf("%d %d: %v differs from %v (ΔE %v)", d, m, got, brettel, e)
+				}
+			}
+		}
+	})
+
+	t.Run("Severity", func(t *testing.T) {
+		c := ColorNRGBA(255, 0, 0, 255)
+
+		full := CVDSimulator{Deficiency: ProtanopiaCVD}.Color(c)
+		half := CVDSimulator{Deficiency: ProtanopiaCVD, Severity: 0.5}.Color(c)
+
+		if !(half.R < c.R && half.R > full.R) {
+			t.Fatalf("half = %v, want between %v and %v", half, c, full)
+		}
+	})
+}
+
+func TestCVDSimulatorImage(t *testing.T) {
+	src := NewImage(2, 1, ColorRed, ColorWhite)
+	s := CVDSimulator{Deficiency: DeuteranopiaCVD, Method: BrettelCVDMethod}
+
+	dst := s.Image(src)
+
+	if got, want := dst.Bounds(), src.Bounds(); got != want {
+		t.Fatalf("Bounds() = %v, want %v", got, want)
+	}
+
+	if got, want := dst.NRGBAAt(0, 0), s.Color(ColorRed); got != want {
+		t.Fatalf("NRGBAAt(0, 0) = %v, want %v", got, want)
+	}
+
+	if got, want := s.Daltonize(src).NRGBAAt(0, 0), s.DaltonizeColor(ColorRed); got != want {
+		t.Fatalf("Daltonize NRGBAAt(0, 0) = %v, want %v", got, want)
+	}
+
+	if got, want := s.Image(image.NewNRGBA(image.Rect(0, 0, 1, 1))).NRGBAAt(0, 0), (color.NRGBA{}); got != want {
+		t.Fatalf("transparent = %v, want %v", got, want)
+	}
+}
+
+func TestCVDSimulatorDaltonizeColor(t *testing.T) {
+	red, green := ColorNRGBA(200, 60, 40, 255), ColorNRGBA(90, 110, 30, 255)
+
+	for _, d := range []CVD{ProtanopiaCVD, DeuteranopiaCVD} {
+		s := CVDSimulator{Deficiency: d}
+
+		distance := func(c1, c2 color.Color) float64 {
+			return ColorToXYZ(s.Color(c1)).CIELab(XYZReference2.D65).DeltaE2000(ColorToXYZ(s.Color(c2)).CIELab(XYZReference2.D65))
+		}
+
+		before := distance(red, green)
+		after := distance(s.DaltonizeColor(red), s.DaltonizeColor(green))
+
+		if after <= before {
+			t.Fatalf("%d: ΔE after daltonization = %v, want more than %v", d, after, before)
+		}
+	}
+
+	if got, want := (CVDSimulator{Deficiency: AchromatopsiaCVD}).DaltonizeColor(red), red; got != want {
+		t.Fatalf("achromatopsia = %v, want %v", got, want)
+	}
+}
+
+func TestContrastRatio(t *testing.T) {
+	for _, tc := range []struct {
+		c1, c2 color.Color
+		want   float64
+	}{
+		{ColorBlack, ColorWhite, 21},
+		{ColorWhite, ColorBlack, 21},
+		{ColorRed, ColorRed, 1},
+		{ColorNRGBA(0x77, 0x77, 0x77, 255), ColorWhite, 4.478},
+		{ColorNRGBA(0, 0, 255, 255), ColorWhite, 8.592},
+	} {
+		if got := ContrastRatio(tc.c1, tc.c2); math.Abs(got-tc.want) > 1e-3 {
+			t.Fatalf("ContrastRatio(%v, %v) = %v, want %v", tc.c1, tc.c2, got, tc.want)
+		}
+	}
+}
+
+func TestPaletteCVDReport(t *testing.T) {
+	p := Palette{
+		{200, 60, 40, 255},
+		{90, 110, 30, 255},
+		{20, 60, 220, 255},
+	}
+
+	got := p.CVDReport(CVDSimulator{Deficiency: DeuteranopiaCVD}, 10)
+
+	if len(got) != 1 || got[0].I != 0 || got[0].J != 1 {
+		t.Fatalf("CVDReport = %+v, want the pair 0, 1", got)
+	}
+
+	if got[0].SimDeltaE >= 10 || got[0].DeltaE < 10 {
+		t.Fatalf("CVDReport = %+v", got)
+	}
+
+	if got := p.CVDReport(CVDSimulator{Deficiency: TritanopiaCVD}, 10); len(got) != 0 {
+		t.Fatalf("CVDReport = %+v, want no pairs", got)
+	}
+}
+
+func absDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+
+	return b - a
+}
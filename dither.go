@@ -0,0 +1,507 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Ditherer converts images into paletted images using a Palette.
+type Ditherer interface {
+	Dither(src image.Image, p Palette) *Paletted
+}
+
+// Dither returns a new image based on the input image, but with colors from
+// the palette, using the provided Ditherer.
+func (p Palette) Dither(src image.Image, d Ditherer) *Paletted {
+	return d.Dither(src, p)
+}
+
+// DiffusionWeight is the weight of the error diffused to the pixel at offset X, Y.
+type DiffusionWeight struct {
+	X, Y   int
+	Weight float64
+}
+
+// DiffusionKernel is a list of weights used for error diffusion dithering.
+//
+// The weights are divided by the Divisor before being applied.
+type DiffusionKernel struct {
+	Divisor float64
+	Weights []DiffusionWeight
+}
+
+// Error diffusion kernels.
+var (
+	DiffusionKernelFloydSteinberg = DiffusionKernel{16, []DiffusionWeight{
+		{1, 0, 7},
+		{-1, 1, 3}, {0, 1, 5}, {1, 1, 1},
+	}}
+
+	DiffusionKernelAtkinson = DiffusionKernel{8, []DiffusionWeight{
+		{1, 0, 1}, {2, 0, 1},
+		{-1, 1, 1}, {0, 1, 1}, {1, 1, 1},
+		{0, 2, 1},
+	}}
+
+	DiffusionKernelJarvisJudiceNinke = DiffusionKernel{48, []DiffusionWeight{
+		{1, 0, 7}, {2, 0, 5},
+		{-2, 1, 3}, {-1, 1, 5}, {0, 1, 7}, {1, 1, 5}, {2, 1, 3},
+		{-2, 2, 1}, {-1, 2, 3}, {0, 2, 5}, {1, 2, 3}, {2, 2, 1},
+	}}
+
+	DiffusionKernelStucki = DiffusionKernel{42, []DiffusionWeight{
+		{1, 0, 8}, {2, 0, 4},
+		{-2, 1, 2}, {-1, 1, 4}, {0, 1, 8}, {1, 1, 4}, {2, 1, 2},
+		{-2, 2, 1}, {-1, 2, 2}, {0, 2, 4}, {1, 2, 2}, {2, 2, 1},
+	}}
+
+	DiffusionKernelBurkes = DiffusionKernel{32, []DiffusionWeight{
+		{1, 0, 8}, {2, 0, 4},
+		{-2, 1, 2}, {-1, 1, 4}, {0, 1, 8}, {1, 1, 4}, {2, 1, 2},
+	}}
+
+	DiffusionKernelSierra = DiffusionKernel{32, []DiffusionWeight{
+		{1, 0, 5}, {2, 0, 3},
+		{-2, 1, 2}, {-1, 1, 4}, {0, 1, 5}, {1, 1, 4}, {2, 1, 2},
+		{-1, 2, 2}, {0, 2, 3}, {1, 2, 2},
+	}}
+
+	DiffusionKernelSierraTwoRow = DiffusionKernel{16, []DiffusionWeight{
+		{1, 0, 4}, {2, 0, 3},
+		{-2, 1, 1}, {-1, 1, 2}, {0, 1, 3}, {1, 1, 2}, {2, 1, 1},
+	}}
+
+	DiffusionKernelSierraLite = DiffusionKernel{4, []DiffusionWeight{
+		{1, 0, 2},
+		{-1, 1, 1}, {0, 1, 1},
+	}}
+)
+
+// ErrorDiffusionDitherer dithers by diffusing the quantization error
+// of each pixel onto its neighbors using the Kernel.
+//
+// Serpentine scanning alternates the direction of every other row.
+type ErrorDiffusionDitherer struct {
+	Kernel     DiffusionKernel
+	Serpentine bool
+}
+
+// Dither the src image using the palette.
+func (ed ErrorDiffusionDitherer) Dither(src image.Image, p Palette) *Paletted {
+	b := src.Bounds()
+	dst := NewPalettedImage(b, p)
+	pt := p.Tree(RGBColorMetric)
+	w := b.Dx()
+
+	rows := 1
+
+	for _, dw := range ed.Kernel.Weights {
+		rows = IntMax(rows, dw.Y+1)
+	}
+
+	// Rolling buffer of the accumulated errors for the current and upcoming rows.
+	errs := make([][]float64, rows)
+
+	for i := range errs {
+		errs[i] = make([]float64, w*3)
+	}
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		reverse := ed.Serpentine && (y-b.Min.Y)%2 == 1
+
+		for i := 0; i < w; i++ {
+			x, dir := i, 1
+
+			if reverse {
+				x, dir = w-1-i, -1
+			}
+
+			c := color.NRGBAModel.Convert(src.At(b.Min.X+x, y)).(color.NRGBA)
+
+			if c.A == 0 {
+				dst.SetColorIndex(b.Min.X+x, y, uint8(pt.Index(c)))
+				continue
+			}
+
+			e := errs[0][x*3 : x*3+3]
+
+			want := [3]float64{
+				float64(c.R) + e[0],
+				float64(c.G) + e[1],
+				float64(c.B) + e[2],
+			}
+
+			n := pt.Index(color.NRGBA{
+				uint8(Clamp(math.Round(want[0]), 0, 255)),
+				uint8(Clamp(math.Round(want[1]), 0, 255)),
+				uint8(Clamp(math.Round(want[2]), 0, 255)),
+				c.A,
+			})
+
+			dst.SetColorIndex(b.Min.X+x, y, uint8(n))
+
+			pc := p[n]
+
+			qe := [3]float64{
+				want[0] - float64(pc.R),
+				want[1] - float64(pc.G),
+				want[2] - float64(pc.B),
+			}
+
+			for _, dw := range ed.Kernel.Weights {
+				nx := x + dw.X*dir
+
+				if nx < 0 || nx >= w || dw.Y >= rows {
+					continue
+				}
+
+				f := dw.Weight / ed.Kernel.Divisor
+				ne := errs[dw.Y][nx*3 : nx*3+3]
+
+				ne[0] += qe[0] * f
+				ne[1] += qe[1] * f
+				ne[2] += qe[2] * f
+			}
+		}
+
+		first := errs[0]
+
+		for i := range first {
+			first[i] = 0
+		}
+
+		errs = append(errs[1:], first)
+	}
+
+	return dst
+}
+
+// ThresholdMatrix is a square matrix of thresholds in the range [0, 1),
+// used for ordered dithering.
+type ThresholdMatrix struct {
+	Size   int
+	Values []float64
+}
+
+// At returns the threshold at x, y, wrapping around the matrix.
+// An empty matrix returns 0.5 for all x, y.
+func (tm ThresholdMatrix) At(x, y int) float64 {
+	if tm.empty() {
+		return 0.5
+	}
+
+	x, y = x%tm.Size, y%tm.Size
+
+	if x < 0 {
+		x += tm.Size
+	}
+
+	if y < 0 {
+		y += tm.Size
+	}
+
+	return tm.Values[y*tm.Size+x]
+}
+
+// empty reports whether the matrix has no (or too few) values.
+func (tm ThresholdMatrix) empty() bool {
+	return tm.Size <= 0 || len(tm.Values) < tm.Size*tm.Size
+}
+
+// orDefault returns the matrix, or ThresholdMatrixBayer4x4 if it is empty.
+func (tm ThresholdMatrix) orDefault() ThresholdMatrix {
+	if tm.empty() {
+		return ThresholdMatrixBayer4x4
+	}
+
+	return tm
+}
+
+// Bayer threshold matrices.
+var (
+	ThresholdMatrixBayer2x2 = NewBayerMatrix(2)
+	ThresholdMatrixBayer4x4 = NewBayerMatrix(4)
+	ThresholdMatrixBayer8x8 = NewBayerMatrix(8)
+)
+
+// NewBayerMatrix creates a Bayer threshold matrix of the given size,
+// rounded up to the nearest power of two.
+func NewBayerMatrix(size int) ThresholdMatrix {
+	m := []int{0}
+	n := 1
+
+	for n < size {
+		next := make([]int, 4*n*n)
+
+		for y := 0; y < n; y++ {
+			for x := 0; x < n; x++ {
+				v := 4 * m[y*n+x]
+
+				next[y*2*n+x] = v
+				next[y*2*n+x+n] = v + 2
+				next[(y+n)*2*n+x] = v + 3
+				next[(y+n)*2*n+x+n] = v + 1
+			}
+		}
+
+		m, n = next, n*2
+	}
+
+	return newThresholdMatrix(n, m)
+}
+
+// NewBlueNoiseMatrix creates a blue noise threshold matrix of the given size,
+// generated with the void-and-cluster method by Robert Ulichney.
+func NewBlueNoiseMatrix(size int, seed int64) ThresholdMatrix {
+	if size <= 0 {
+		return ThresholdMatrix{}
+	}
+
+	n := size * size
+
+	const sigma = 1.5
+
+	// Gaussian weights for each toroidal offset.
+	kernel := make([]float64, n)
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			dx, dy := float64(IntMin(x, size-x)), float64(IntMin(y, size-y))
+
+			kernel[y*size+x] = math.Exp(-(dx*dx + dy*dy) / (2 * sigma * sigma))
+		}
+	}
+
+	update := func(energy []float64, i int, sign float64) {
+		ix, iy := i%size, i/size
+
+		for y := 0; y < size; y++ {
+			for x := 0; x < size; x++ {
+				k := kernel[((y-iy+size)%size)*size+(x-ix+size)%size]
+
+				energy[y*size+x] += sign * k
+			}
+		}
+	}
+
+	extreme := func(pattern []bool, energy []float64, set bool) int {
+		best, bi := 0.0, -1
+
+		for i, v := range pattern {
+			if v != set {
+				continue
+			}
+
+			if bi < 0 || (set && energy[i] > best) || (!set && energy[i] < best) {
+				best, bi = energy[i], i
+			}
+		}
+
+		return bi
+	}
+
+	// Initial binary pattern.
+	r := rand.New(rand.NewSource(seed))
+
+	pattern := make([]bool, n)
+	energy := make([]float64, n)
+
+	ones := IntMax(1, n/10)
+
+	for _, i := range r.Perm(n)[:ones] {
+		pattern[i] = true
+		update(energy, i, 1)
+	}
+
+	// Move the tightest clusters into the largest voids until stable.
+	for iter := 0; iter < n*4; iter++ {
+		c := extreme(pattern, energy, true)
+
+		pattern[c] = false
+		update(energy, c, -1)
+
+		v := extreme(pattern, energy, false)
+
+		pattern[v] = true
+		update(energy, v, 1)
+
+		if v == c {
+			break
+		}
+	}
+
+	ranks := make([]int, n)
+
+	// Rank the initial pattern by removing the tightest clusters.
+	p := append([]bool{}, pattern...)
+	e := append([]float64{}, energy...)
+
+	for rank := ones - 1; rank >= 0; rank-- {
+		c := extreme(p, e, true)
+
+		p[c] = false
+		update(e, c, -1)
+
+		ranks[c] = rank
+	}
+
+	// Rank the rest by filling the largest voids.
+	for rank := ones; rank < n; rank++ {
+		v := extreme(pattern, energy, false)
+
+		pattern[v] = true
+		update(energy, v, 1)
+
+		ranks[v] = rank
+	}
+
+	return newThresholdMatrix(size, ranks)
+}
+
+func newThresholdMatrix(size int, ranks []int) ThresholdMatrix {
+	n := float64(len(ranks))
+	values := make([]float64, len(ranks))
+
+	for i, r := range ranks {
+		values[i] = (float64(r) + 0.5) / n
+	}
+
+	return ThresholdMatrix{Size: size, Values: values}
+}
+
+// OrderedDitherer dithers by offsetting each pixel based on the
+// threshold Matrix before looking up the closest palette color.
+//
+// Spread is the amount (in the range 0-255) that colors are offset.
+// A Spread of zero means that it is derived from the size of the palette,
+// and an empty Matrix is treated as ThresholdMatrixBayer4x4.
+type OrderedDitherer struct {
+	Matrix ThresholdMatrix
+	Spread float64
+}
+
+// Dither the src image using the palette.
+func (od OrderedDitherer) Dither(src image.Image, p Palette) *Paletted {
+	b := src.Bounds()
+	dst := NewPalettedImage(b, p)
+	pt := p.Tree(RGBColorMetric)
+	tm := od.Matrix.orDefault()
+
+	spread := od.Spread
+
+	if spread == 0 && p.Len() > 0 {
+		spread = 255 / math.Cbrt(float64(p.Len()))
+	}
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(src.At(x, y)).(color.NRGBA)
+
+			o := spread * (tm.At(x-b.Min.X, y-b.Min.Y) - 0.5)
+
+			dst.SetColorIndex(x, y, uint8(pt.Index(color.NRGBA{
+				uint8(Clamp(math.Round(float64(c.R)+o), 0, 255)),
+				uint8(Clamp(math.Round(float64(c.G)+o), 0, 255)),
+				uint8(Clamp(math.Round(float64(c.B)+o), 0, 255)),
+				c.A,
+			})))
+		}
+	}
+
+	return dst
+}
+
+// PatternDitherer dithers using Yliluoma's ordered dithering algorithm 2,
+// where each color is approximated by a mix of palette colors, ordered by
+// luminance and selected based on the threshold Matrix. An empty Matrix
+// is treated as ThresholdMatrixBayer4x4.
+//
+// https://bisqwit.iki.fi/story/howto/dither/jy/
+type PatternDitherer struct {
+	Matrix ThresholdMatrix
+}
+
+// Dither the src image using the palette.
+func (pd PatternDitherer) Dither(src image.Image, p Palette) *Paletted {
+	b := src.Bounds()
+	dst := NewPalettedImage(b, p)
+
+	if p.Len() == 0 {
+		return dst
+	}
+
+	tm := pd.Matrix.orDefault()
+
+	n := IntClamp(tm.Size*tm.Size, 1, 64)
+
+	plans := map[color.NRGBA][]uint8{}
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(src.At(x, y)).(color.NRGBA)
+
+			plan, ok := plans[c]
+			if !ok {
+				plan = ditherPlan(c, p, n)
+				plans[c] = plan
+			}
+
+			// Clamped in case the matrix has values outside of [0, 1).
+			i := IntClamp(int(tm.At(x-b.Min.X, y-b.Min.Y)*float64(len(plan))), 0, len(plan)-1)
+
+			dst.SetColorIndex(x, y, plan[i])
+		}
+	}
+
+	return dst
+}
+
+// ditherPlan returns n palette indexes whose average approximates c,
+// sorted by luminance.
+func ditherPlan(c color.NRGBA, p Palette, n int) []uint8 {
+	if c.A == 0 {
+		return []uint8{uint8(p.Index(c))}
+	}
+
+	target := [3]float64{float64(c.R), float64(c.G), float64(c.B)}
+
+	var sum [3]float64
+
+	plan := make([]uint8, 0, n)
+
+	for k := 1; k <= n; k++ {
+		best, bestDist := 0, math.Inf(1)
+
+		for i, pc := range p {
+			var dist float64
+
+			for j, v := range [3]float64{float64(pc.R), float64(pc.G), float64(pc.B)} {
+				d := (sum[j]+v)/float64(k) - target[j]
+				dist += d * d
+			}
+
+			if dist < bestDist {
+				best, bestDist = i, dist
+			}
+		}
+
+		pc := p[best]
+
+		sum[0] += float64(pc.R)
+		sum[1] += float64(pc.G)
+		sum[2] += float64(pc.B)
+
+		plan = append(plan, uint8(best))
+	}
+
+	sort.SliceStable(plan, func(i, j int) bool {
+		a, b := p[plan[i]], p[plan[j]]
+
+		return 299*int(a.R)+587*int(a.G)+114*int(a.B) < 299*int(b.R)+587*int(b.G)+114*int(b.B)
+	})
+
+	return plan
+}
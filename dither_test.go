@@ -0,0 +1,95 @@
+package gfx
+
+import (
+	"image"
+	"testing"
+)
+
+func TestPaletteDither(t *testing.T) {
+	p := Palette{ColorBlack, ColorWhite}
+
+	src := NewUniform(ColorNRGBA(128, 128, 128, 255))
+	m := image.NewNRGBA(IR(0, 0, 16, 16))
+
+	DrawSrc(m, m.Bounds(), src, ZP)
+
+	for _, tc := range []struct {
+		name string
+		d    Ditherer
+	}{
+		{"FloydSteinberg", ErrorDiffusionDitherer{Kernel: DiffusionKernelFloydSteinberg}},
+		{"Serpentine", ErrorDiffusionDitherer{Kernel: DiffusionKernelSierra, Serpentine: true}},
+		{"Bayer", OrderedDitherer{Matrix: ThresholdMatrixBayer4x4}},
+		{"BlueNoise", OrderedDitherer{Matrix: NewBlueNoiseMatrix(8, 1)}},
+		{"Pattern", PatternDitherer{Matrix: ThresholdMatrixBayer4x4}},
+		{"ZeroOrdered", OrderedDitherer{}},
+		{"ZeroPattern", PatternDitherer{}},
+		{"EmptyBlueNoise", OrderedDitherer{Matrix: NewBlueNoiseMatrix(0, 1)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pm := p.Dither(m, tc.d)
+
+			var white int
+
+			for _, ci := range pm.Pix {
+				white += int(ci)
+			}
+
+			if white < 112 || white > 144 {
+				t.Fatalf("white = %d, want about 128", white)
+			}
+		})
+	}
+}
+
+func TestNewBayerMatrix(t *testing.T) {
+	tm := NewBayerMatrix(2)
+
+	for i, want := range []float64{0.125, 0.625, 0.875, 0.375} {
+		if got := tm.Values[i]; got != want {
+			t.Fatalf("tm.Values[%d] = %v, want %v", i, got, want)
+		}
+	}
+
+	if got, want := NewBayerMatrix(5).Size, 8; got != want {
+		t.Fatalf("NewBayerMatrix(5).Size = %d, want %d", got, want)
+	}
+}
+
+func TestThresholdMatrixAt(t *testing.T) {
+	if got, want := (ThresholdMatrix{}).At(1, 1), 0.5; got != want {
+		t.Fatalf("ThresholdMatrix{}.At(1, 1) = %v, want %v", got, want)
+	}
+
+	if got, want := ThresholdMatrixBayer2x2.At(-1, 3), 0.375; got != want {
+		t.Fatalf("At(-1, 3) = %v, want %v", got, want)
+	}
+}
+
+func TestPatternDithererRange(t *testing.T) {
+	p := Palette{ColorBlack, ColorWhite}
+	m := NewImage(2, 2, ColorNRGBA(128, 128, 128, 255))
+
+	// Thresholds outside of [0, 1) use the first or last color of the plan.
+	for _, v := range []float64{-0.5, 1, 1.5} {
+		pm := p.Dither(m, PatternDitherer{Matrix: ThresholdMatrix{Size: 1, Values: []float64{v}}})
+
+		if got, want := len(pm.Pix), 4; got != want {
+			t.Fatalf("len(pm.Pix) = %d, want %d", got, want)
+		}
+	}
+}
+
+func TestNewBlueNoiseMatrix(t *testing.T) {
+	tm := NewBlueNoiseMatrix(8, 1)
+
+	seen := map[float64]bool{}
+
+	for _, v := range tm.Values {
+		seen[v] = true
+	}
+
+	if got, want := len(seen), 64; got != want {
+		t.Fatalf("len(seen) = %d, want %d", got, want)
+	}
+}
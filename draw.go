@@ -69,6 +69,16 @@ func DrawLine(dst draw.Image, from, to Vec, thickness float64, c color.Color) {
 	polylineFromTo(from, to, thickness).Fill(dst, c)
 }
 
+// DrawLineAA draws an anti-aliased line of the given color.
+// A thickness of <= 1 is drawn as a line one pixel wide.
+func DrawLineAA(dst draw.Image, from, to Vec, thickness float64, c color.Color) {
+	if thickness <= 1 {
+		thickness = 0.5
+	}
+
+	Polyline{polylineFromTo(from, to, thickness)}.FillAA(dst, c, NonZeroFillRule)
+}
+
 // DrawTriangles draws triangles on dst.
 func DrawTriangles(dst draw.Image, triangles []Triangle) {
 	for _, t := range triangles {
@@ -102,13 +112,7 @@ func DrawPolygon(dst draw.Image, p Polygon, thickness float64, c color.Color) {
 	case thickness < 1:
 		p.Fill(dst, c)
 	default:
-		for i := 0; i < n; i++ {
-			if i+1 == n {
-				polylineFromTo(p[n-1], p[0], thickness).Fill(dst, c)
-			} else {
-				polylineFromTo(p[i], p[i+1], thickness).Fill(dst, c)
-			}
-		}
+		Stroke{Width: 2 * thickness}.Outline(p, true).Fill(dst, c, NonZeroFillRule)
 	}
 }
 
@@ -119,6 +123,30 @@ func DrawPolyline(dst draw.Image, pl Polyline, thickness float64, c color.Color)
 	}
 }
 
+// DrawPolygonAA draws an anti-aliased polygon,
+// filled or as line polygons if the thickness is >= 1.
+func DrawPolygonAA(dst draw.Image, p Polygon, thickness float64, c color.Color) {
+	n := len(p)
+
+	if n < 3 {
+		return
+	}
+
+	switch {
+	case thickness < 1:
+		p.FillAA(dst, c)
+	default:
+		Stroke{Width: 2 * thickness}.Outline(p, true).FillAA(dst, c, NonZeroFillRule)
+	}
+}
+
+// DrawPolylineAA draws an anti-aliased polyline with the given color and thickness.
+func DrawPolylineAA(dst draw.Image, pl Polyline, thickness float64, c color.Color) {
+	for _, p := range pl {
+		DrawPolygonAA(dst, p, thickness, c)
+	}
+}
+
 // DrawCircle draws a circle with radius and thickness. (filled if thickness == 0)
 func DrawCircle(dst draw.Image, u Vec, radius, thickness float64, c color.Color) {
 	if thickness == 0 {
@@ -4,12 +4,14 @@ import (
 	"image"
 	"image/color"
 	"image/draw"
+	"math"
 )
 
 // DrawTarget draws to a draw.Image, projected through a Matrix.
 type DrawTarget struct {
-	dst draw.Image
-	mat Matrix
+	dst    draw.Image
+	mat    Matrix
+	smooth bool
 }
 
 var _ BasicTarget = (*DrawTarget)(nil)
@@ -27,6 +29,17 @@ func (dt *DrawTarget) SetMatrix(mat Matrix) {
 	dt.mat = mat
 }
 
+// SetSmooth sets whether pictures are sampled using bilinear
+// interpolation (true) or nearest neighbor (false, the default).
+func (dt *DrawTarget) SetSmooth(smooth bool) {
+	dt.smooth = smooth
+}
+
+// Smooth returns whether pictures are sampled using bilinear interpolation.
+func (dt *DrawTarget) Smooth() bool {
+	return dt.smooth
+}
+
 // Bounds of the draw target.
 func (dt *DrawTarget) Bounds() image.Rectangle {
 	return dt.dst.Bounds()
@@ -56,9 +69,21 @@ func (dt *DrawTarget) Set(x, y int, c color.Color) {
 	dt.dst.Set(p.X, p.Y, c)
 }
 
+// DrawImage draws the src image transformed by the matrix of the DrawTarget.
+func (dt *DrawTarget) DrawImage(src image.Image, filter ResampleFilter) {
+	DrawTransformed(dt.dst, src, dt.mat, filter)
+}
+
 // MakePicture creates a TargetPicture for the provided Picture.
+//
+// The Picture needs to be a PictureColor, such as an *ImagePicture.
 func (dt *DrawTarget) MakePicture(pic Picture) TargetPicture {
-	panic(Error("*DrawTarget: not implemented yet."))
+	pc, ok := pic.(PictureColor)
+	if !ok {
+		panic(Errorf("(%T).MakePicture: %T is not a PictureColor", dt, pic))
+	}
+
+	return &targetPicture{PictureColor: pc, dt: dt}
 }
 
 // MakeTriangles creates TargetTriangles for the given Triangles
@@ -91,3 +116,71 @@ func (tt *targetTriangles) Draw() {
 		}
 	}
 }
+
+type targetPicture struct {
+	PictureColor
+	dt *DrawTarget
+}
+
+func (tp *targetPicture) Draw(t TargetTriangles) {
+	tt, ok := t.(*targetTriangles)
+	if !ok || tt.dt != tp.dt {
+		panic(Errorf("(%T).Draw: TargetTriangles generated by different DrawTarget", tp))
+	}
+
+	td := MakeTrianglesData(tt.Len())
+
+	td.Update(tt.Triangles)
+
+	for i := 0; i+2 < td.Len(); i += 3 {
+		tri := NewTriangle(i, td)
+
+		for j := range tri {
+			tri[j].Position = tp.dt.mat.Project(tri[j].Position)
+		}
+
+		b := tri.Bounds().Inset(-1).Intersect(tp.dt.dst.Bounds())
+
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				if u := IV(x, y).AddXY(0.5, 0.5); tri.Contains(u) {
+					if c := tp.color(tri, u); c.A > 0 {
+						Mix(tp.dt.dst, x, y, c)
+					}
+				}
+			}
+		}
+	}
+}
+
+// color returns the vertex color at u multiplied by the
+// picture color, weighted by the interpolated intensity.
+func (tp *targetPicture) color(t Triangle, u Vec) color.NRGBA {
+	a, b, c := t.Barycentric(u)
+
+	pos := t[0].Picture.Scaled(a).Add(t[1].Picture.Scaled(b)).Add(t[2].Picture.Scaled(c))
+	in := Clamp(t[0].Intensity*a+t[1].Intensity*b+t[2].Intensity*c, 0, 1)
+
+	var pc color.NRGBA
+
+	if tp.dt.smooth {
+		pc = PictureColorBilinear(tp.PictureColor, pos)
+	} else {
+		pc = tp.PictureColor.Color(pos)
+	}
+
+	channel := func(v0, v1, v2, p uint8) uint8 {
+		v := float64(v0)*a + float64(v1)*b + float64(v2)*c
+
+		return uint8(Clamp(math.Round(v*Lerp(1, float64(p)/255, in)), 0, 255))
+	}
+
+	c0, c1, c2 := t.Colors()
+
+	return color.NRGBA{
+		channel(c0.R, c1.R, c2.R, pc.R),
+		channel(c0.G, c1.G, c2.G, pc.G),
+		channel(c0.B, c1.B, c2.B, pc.B),
+		channel(c0.A, c1.A, c2.A, pc.A),
+	}
+}
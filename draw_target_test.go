@@ -0,0 +1,89 @@
+package gfx
+
+import "testing"
+
+func TestDrawTargetMakePicture(t *testing.T) {
+	src := NewImage(4, 4, ColorRed)
+
+	src.Set(1, 2, ColorBlue)
+
+	td := &TrianglesData{
+		Vx(V(0, 0), V(0, 0), 1.0, ColorWhite),
+		Vx(V(4, 0), V(4, 0), 1.0, ColorWhite),
+		Vx(V(4, 4), V(4, 4), 1.0, ColorWhite),
+		Vx(V(0, 0), V(0, 0), 1.0, ColorWhite),
+		Vx(V(4, 4), V(4, 4), 1.0, ColorWhite),
+		Vx(V(0, 4), V(0, 4), 1.0, ColorWhite),
+	}
+
+	dst := NewImage(8, 8, ColorBlack)
+	dt := NewDrawTarget(dst)
+
+	dt.SetMatrix(IM.Moved(V(2, 2)))
+
+	d := Drawer{Triangles: td, Picture: NewImagePicture(src)}
+
+	d.Draw(dt)
+
+	for _, tc := range []struct {
+		x, y int
+		want uint8
+	}{
+		{0, 0, 0},
+		{2, 2, 255},
+		{3, 4, 0},
+		{5, 5, 255},
+		{6, 6, 0},
+	} {
+		if got := dst.RGBAAt(tc.x, tc.y).R; got != tc.want {
+			t.Fatalf("dst.RGBAAt(%d, %d).R = %d, want %d", tc.x, tc.y, got, tc.want)
+		}
+	}
+
+	if got, want := dst.RGBAAt(3, 4).B, uint8(255); got != want {
+		t.Fatalf("dst.RGBAAt(3, 4).B = %d, want %d", got, want)
+	}
+}
+
+func TestDrawTargetMakePictureSmooth(t *testing.T) {
+	src := NewImage(2, 1)
+
+	src.Set(0, 0, ColorBlack)
+	src.Set(1, 0, ColorWhite)
+
+	td := &TrianglesData{
+		Vx(V(0, 0), V(0, 0), 1.0, ColorWhite),
+		Vx(V(4, 0), V(2, 0), 1.0, ColorWhite),
+		Vx(V(4, 2), V(2, 1), 1.0, ColorWhite),
+		Vx(V(0, 0), V(0, 0), 1.0, ColorWhite),
+		Vx(V(4, 2), V(2, 1), 1.0, ColorWhite),
+		Vx(V(0, 2), V(0, 1), 1.0, ColorWhite),
+	}
+
+	dst := NewImage(4, 2)
+	dt := NewDrawTarget(dst)
+
+	dt.SetSmooth(true)
+
+	if !dt.Smooth() {
+		t.Fatalf("expected draw target to be smooth")
+	}
+
+	dt.MakePicture(NewImagePicture(src)).Draw(dt.MakeTriangles(td))
+
+	if got := dst.RGBAAt(1, 0).R; got == 0 || got == 255 {
+		t.Fatalf("dst.RGBAAt(1, 0).R = %d, want interpolated value", got)
+	}
+}
+
+func TestImagePictureColor(t *testing.T) {
+	ip := NewImagePicture(NewImage(2, 2, ColorGreen))
+
+	if got, want := ip.Color(V(1.5, 0.5)), ColorGreen; got != want {
+		t.Fatalf("ip.Color(V(1.5, 0.5)) = %v, want %v", got, want)
+	}
+
+	if got, want := ip.Color(V(2.5, 0.5)), ColorTransparent; got != want {
+		t.Fatalf("ip.Color(V(2.5, 0.5)) = %v, want %v", got, want)
+	}
+}
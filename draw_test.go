@@ -58,6 +58,19 @@ func TestDrawPolygon(t *testing.T) {
 
 	DrawPolygon(dst, p, 0, ColorMagenta)
 	DrawPolygon(dst, p, 1, ColorYellow)
+
+	t.Run("Joins", func(t *testing.T) {
+		dst := NewImage(40, 40, ColorBlack)
+
+		DrawPolygon(dst, Polygon{{10, 10}, {30, 10}, {30, 30}, {10, 30}}, 3, ColorWhite)
+
+		// The outer corners are only filled when the segments are joined.
+		for _, pt := range [][2]int{{8, 8}, {31, 8}, {31, 31}, {8, 31}} {
+			if got := dst.RGBAAt(pt[0], pt[1]); got.R != 255 {
+				t.Fatalf("dst.RGBAAt(%d, %d) = %v, want white", pt[0], pt[1], got)
+			}
+		}
+	})
 }
 
 func TestDrawPolyline(t *testing.T) {
@@ -0,0 +1,231 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Connectivity is the set of neighbors considered connected to a pixel.
+type Connectivity int
+
+const (
+	// FourConnectivity connects pixels horizontally and vertically.
+	FourConnectivity Connectivity = iota
+
+	// EightConnectivity also connects pixels diagonally.
+	EightConnectivity
+)
+
+// scanlineFill visits all of the pixels within r that are connected to p
+// and for which inside returns true, calling set for each of them.
+//
+// Each pixel is visited at most once. The visited pixels are marked in the
+// visited buffer (one value per pixel in r, row by row), which may be shared
+// between calls, and a nil buffer is allocated. The number of visited pixels
+// is returned.
+func scanlineFill(r image.Rectangle, p image.Point, conn Connectivity, visited []bool, inside func(x, y int) bool, set func(x, y int)) int {
+	if !p.In(r) {
+		return 0
+	}
+
+	w := r.Dx()
+
+	if visited == nil {
+		visited = make([]bool, w*r.Dy())
+	}
+
+	ok := func(x, y int) bool {
+		return x >= r.Min.X && x < r.Max.X && !visited[(y-r.Min.Y)*w+x-r.Min.X] && inside(x, y)
+	}
+
+	d := 0
+
+	if conn == EightConnectivity {
+		d = 1
+	}
+
+	var count int
+
+	stack := []image.Point{p}
+
+	for len(stack) > 0 {
+		q := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if !ok(q.X, q.Y) {
+			continue
+		}
+
+		lx, rx := q.X, q.X
+
+		for ok(lx-1, q.Y) {
+			lx--
+		}
+
+		for ok(rx+1, q.Y) {
+			rx++
+		}
+
+		for x := lx; x <= rx; x++ {
+			visited[(q.Y-r.Min.Y)*w+x-r.Min.X] = true
+			set(x, q.Y)
+			count++
+		}
+
+		for _, y := range []int{q.Y - 1, q.Y + 1} {
+			if y < r.Min.Y || y >= r.Max.Y {
+				continue
+			}
+
+			run := false
+
+			for x := lx - d; x <= rx+d; x++ {
+				if ok(x, y) {
+					if !run {
+						stack = append(stack, image.Pt(x, y))
+					}
+
+					run = true
+				} else {
+					run = false
+				}
+			}
+		}
+	}
+
+	return count
+}
+
+// FloodFill fills the area connected to p with the color c, where the
+// color of each pixel is within the tolerance (CIELab.DeltaE, with the
+// difference in alpha counted on the same 0-100 scale) of the color at p.
+//
+// The number of filled pixels is returned.
+func FloodFill(dst draw.Image, p image.Point, c color.Color, tolerance float64, conn Connectivity) int {
+	if !p.In(dst.Bounds()) {
+		return 0
+	}
+
+	m := CIELabColorMetric
+
+	start := m.point(dst.At(p.X, p.Y))
+
+	return scanlineFill(dst.Bounds(), p, conn, nil, func(x, y int) bool {
+		return m.distance(start, m.point(dst.At(x, y))) <= tolerance*tolerance
+	}, func(x, y int) {
+		dst.Set(x, y, c)
+	})
+}
+
+// FloodFillIndex fills the area connected to p with the color index,
+// where the pixels have the same index as the pixel at p.
+//
+// The number of filled pixels is returned.
+func FloodFillIndex(dst PalettedDrawImage, p image.Point, index uint8, conn Connectivity) int {
+	if !p.In(dst.Bounds()) {
+		return 0
+	}
+
+	start := dst.ColorIndexAt(p.X, p.Y)
+
+	if start == index {
+		return 0
+	}
+
+	return scanlineFill(dst.Bounds(), p, conn, nil, func(x, y int) bool {
+		return dst.ColorIndexAt(x, y) == start
+	}, func(x, y int) {
+		dst.SetColorIndex(x, y, index)
+	})
+}
+
+// BoundaryFill fills the area connected to p with the color c,
+// stopping at pixels with the boundary color.
+//
+// The number of filled pixels is returned.
+func BoundaryFill(dst draw.Image, p image.Point, c, boundary color.Color, conn Connectivity) int {
+	b := color.NRGBA64Model.Convert(boundary)
+
+	return scanlineFill(dst.Bounds(), p, conn, nil, func(x, y int) bool {
+		return color.NRGBA64Model.Convert(dst.At(x, y)) != b
+	}, func(x, y int) {
+		dst.Set(x, y, c)
+	})
+}
+
+// Component is a connected region of pixels.
+type Component struct {
+	Label  int
+	Bounds image.Rectangle
+	Count  int
+}
+
+// ComponentLabels holds the label of each pixel. Label 0 is the background,
+// and label n is the component at index n-1.
+type ComponentLabels struct {
+	Rect   image.Rectangle
+	Labels []int
+}
+
+// Label returns the label at x, y.
+func (cl *ComponentLabels) Label(x, y int) int {
+	if !(image.Point{x, y}.In(cl.Rect)) {
+		return 0
+	}
+
+	return cl.Labels[(y-cl.Rect.Min.Y)*cl.Rect.Dx()+x-cl.Rect.Min.X]
+}
+
+// LabelComponents labels the connected components of the pixels in src
+// for which foreground returns true. A nil foreground function means
+// that all non transparent pixels are in the foreground.
+func LabelComponents(src image.Image, conn Connectivity, foreground func(c color.Color) bool) ([]Component, *ComponentLabels) {
+	if foreground == nil {
+		foreground = func(c color.Color) bool {
+			_, _, _, a := c.RGBA()
+
+			return a > 0
+		}
+	}
+
+	r := src.Bounds()
+	w := r.Dx()
+
+	cl := &ComponentLabels{Rect: r, Labels: make([]int, w*r.Dy())}
+
+	fg := make([]bool, len(cl.Labels))
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			fg[(y-r.Min.Y)*w+x-r.Min.X] = foreground(src.At(x, y))
+		}
+	}
+
+	var components []Component
+
+	visited := make([]bool, len(cl.Labels))
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			i := (y-r.Min.Y)*w + x - r.Min.X
+
+			if !fg[i] || cl.Labels[i] != 0 {
+				continue
+			}
+
+			c := Component{Label: len(components) + 1, Bounds: image.Rect(x, y, x+1, y+1)}
+
+			c.Count = scanlineFill(r, image.Pt(x, y), conn, visited, func(x, y int) bool {
+				return fg[(y-r.Min.Y)*w+x-r.Min.X]
+			}, func(x, y int) {
+				cl.Labels[(y-r.Min.Y)*w+x-r.Min.X] = c.Label
+				c.Bounds = c.Bounds.Union(image.Rect(x, y, x+1, y+1))
+			})
+
+			components = append(components, c)
+		}
+	}
+
+	return components, cl
+}
@@ -0,0 +1,117 @@
+package gfx
+
+import (
+	"image"
+	"testing"
+)
+
+func TestFloodFill(t *testing.T) {
+	newImage := func() *image.NRGBA {
+		m := NewNRGBA(IR(0, 0, 5, 5))
+
+		DrawColor(m, m.Bounds(), ColorWhite)
+
+		// A diagonal wall of black pixels, and a slightly off-white pixel.
+		for i := 0; i < 5; i++ {
+			m.SetNRGBA(i, 4-i, ColorBlack)
+		}
+
+		m.SetNRGBA(0, 1, ColorNRGBA(250, 250, 250, 255))
+
+		return m
+	}
+
+	for _, tc := range []struct {
+		name      string
+		tolerance float64
+		conn      Connectivity
+		want      int
+	}{
+		{"Exact", 0, FourConnectivity, 9},
+		{"Tolerance", 5, FourConnectivity, 10},
+		{"EightConnectivity", 5, EightConnectivity, 20},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newImage()
+
+			if got := FloodFill(m, image.Pt(0, 0), ColorRed, tc.tolerance, tc.conn); got != tc.want {
+				t.Fatalf("FloodFill() = %d, want %d", got, tc.want)
+			}
+
+			if got, want := m.NRGBAAt(0, 0), ColorRed; got != want {
+				t.Fatalf("m.NRGBAAt(0, 0) = %v, want %v", got, want)
+			}
+		})
+	}
+
+	t.Run("BoundaryFill", func(t *testing.T) {
+		m := newImage()
+
+		if got, want := BoundaryFill(m, image.Pt(4, 4), ColorRed, ColorBlack, FourConnectivity), 10; got != want {
+			t.Fatalf("BoundaryFill() = %d, want %d", got, want)
+		}
+	})
+}
+
+func TestFloodFillIndex(t *testing.T) {
+	m := NewTile(Palette1Bit, 4, []uint8{
+		0, 0, 1, 0,
+		0, 1, 0, 0,
+		1, 1, 0, 1,
+	})
+
+	if got, want := FloodFillIndex(m, image.Pt(0, 0), 1, FourConnectivity), 3; got != want {
+		t.Fatalf("FloodFillIndex() = %d, want %d", got, want)
+	}
+
+	if got, want := m.Index(1, 0), uint8(1); got != want {
+		t.Fatalf("m.Index(1, 0) = %d, want %d", got, want)
+	}
+
+	if got, want := m.Index(3, 0), uint8(0); got != want {
+		t.Fatalf("m.Index(3, 0) = %d, want %d", got, want)
+	}
+}
+
+func TestLabelComponents(t *testing.T) {
+	m := NewNRGBA(IR(0, 0, 6, 4))
+
+	DrawColor(m, IR(0, 0, 2, 2), ColorRed)
+	DrawColor(m, IR(2, 2, 3, 3), ColorGreen)
+	DrawColor(m, IR(4, 0, 6, 4), ColorBlue)
+
+	for _, tc := range []struct {
+		conn Connectivity
+		want []Component
+	}{
+		{FourConnectivity, []Component{
+			{1, IR(0, 0, 2, 2), 4},
+			{2, IR(4, 0, 6, 4), 8},
+			{3, IR(2, 2, 3, 3), 1},
+		}},
+		{EightConnectivity, []Component{
+			{1, IR(0, 0, 3, 3), 5},
+			{2, IR(4, 0, 6, 4), 8},
+		}},
+	} {
+		components, labels := LabelComponents(m, tc.conn, nil)
+
+		if got, want := len(components), len(tc.want); got != want {
+			t.Fatalf("len(components) = %d, want %d", got, want)
+		}
+
+		for i, want := range tc.want {
+			if got := components[i]; got != want {
+				t.Fatalf("components[%d] = %v, want %v", i, got, want)
+			}
+		}
+
+		if got, want := labels.Label(5, 3), 2; got != want {
+			t.Fatalf("labels.Label(5, 3) = %d, want %d", got, want)
+		}
+
+		if got, want := labels.Label(3, 0), 0; got != want {
+			t.Fatalf("labels.Label(3, 0) = %d, want %d", got, want)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package gfx
+
+import "math"
+
+// FractalType is the way octaves of noise are combined by a Fractal.
+type FractalType int
+
+// Fractal types
+const (
+	// FBMFractalType is fractional Brownian motion, the plain sum of the octaves.
+	// (range [-1, 1])
+	FBMFractalType FractalType = iota
+
+	// RidgedFractalType is ridged multifractal noise, where each octave
+	// is (Offset - |noise|)² weighted by the previous octave.
+	// (range [0, Offset²])
+	RidgedFractalType
+
+	// BillowFractalType is the sum of 2|noise|-1 of the octaves.
+	// (range [-1, 1])
+	BillowFractalType
+
+	// TurbulenceFractalType is the sum of |noise| of the octaves.
+	// (range [0, 1])
+	TurbulenceFractalType
+
+	// HybridFractalType is hybrid multifractal noise, where each octave
+	// is noise + Offset weighted by the previous octaves.
+	HybridFractalType
+)
+
+// Fractal combines octaves of noise into fractal noise. Each octave has its
+// frequency multiplied by the Lacunarity and its amplitude by the Gain.
+//
+// The zero values of Octaves, Lacunarity, Gain and Offset are treated as
+// 6, 2, 0.5 and 1 respectively.
+type Fractal struct {
+	Type       FractalType
+	Octaves    int
+	Lacunarity float64
+	Gain       float64
+	Offset     float64
+}
+
+// Fractal2D returns fractal noise using the 2D noise source.
+func (f Fractal) Fractal2D(src Noise2D) Noise2DFunc {
+	return func(x, y float64) float64 {
+		return f.sum(func(freq float64) float64 {
+			return src.Noise2D(x*freq, y*freq)
+		})
+	}
+}
+
+// Fractal3D returns fractal noise using the 3D noise source.
+func (f Fractal) Fractal3D(src Noise3D) Noise3DFunc {
+	return func(x, y, z float64) float64 {
+		return f.sum(func(freq float64) float64 {
+			return src.Noise3D(x*freq, y*freq, z*freq)
+		})
+	}
+}
+
+// Fractal4D returns fractal noise using the 4D noise source.
+func (f Fractal) Fractal4D(src Noise4D) Noise4DFunc {
+	return func(x, y, z, w float64) float64 {
+		return f.sum(func(freq float64) float64 {
+			return src.Noise4D(x*freq, y*freq, z*freq, w*freq)
+		})
+	}
+}
+
+// sum the octaves of the noise returned by the octave func for each frequency.
+func (f Fractal) sum(octave func(freq float64) float64) float64 {
+	octaves, lacunarity, gain, offset := f.Octaves, f.Lacunarity, f.Gain, f.Offset
+
+	if octaves <= 0 {
+		octaves = 6
+	}
+
+	if lacunarity == 0 {
+		lacunarity = 2
+	}
+
+	if gain == 0 {
+		gain = 0.5
+	}
+
+	if offset == 0 {
+		offset = 1
+	}
+
+	var sum, norm float64
+
+	amp, freq, weight := 1.0, 1.0, 1.0
+
+	for i := 0; i < octaves; i++ {
+		n := octave(freq)
+
+		switch f.Type {
+		case RidgedFractalType:
+			s := offset - math.Abs(n)
+			s *= s * weight
+
+			weight = Clamp(s*2, 0, 1)
+			n = s
+		case BillowFractalType:
+			n = 2*math.Abs(n) - 1
+		case TurbulenceFractalType:
+			n = math.Abs(n)
+		case HybridFractalType:
+			s := n + offset
+
+			if i == 0 {
+				n, weight = s, s
+			} else {
+				n = s * MathMin(weight, 1)
+				weight *= s * amp
+			}
+		}
+
+		sum += n * amp
+		norm += amp
+
+		amp *= gain
+		freq *= lacunarity
+	}
+
+	return sum / norm
+}
+
+// DomainWarp2D returns the 2D noise source sampled at points displaced by
+// the warp noise, scaled by the strength.
+func DomainWarp2D(src, warp Noise2D, strength float64) Noise2DFunc {
+	return func(x, y float64) float64 {
+		dx := warp.Noise2D(x, y)
+		dy := warp.Noise2D(x+5.2, y+1.3)
+
+		return src.Noise2D(x+dx*strength, y+dy*strength)
+	}
+}
+
+// DomainWarp3D returns the 3D noise source sampled at points displaced by
+// the warp noise, scaled by the strength.
+func DomainWarp3D(src, warp Noise3D, strength float64) Noise3DFunc {
+	return func(x, y, z float64) float64 {
+		dx := warp.Noise3D(x, y, z)
+		dy := warp.Noise3D(x+5.2, y+1.3, z+2.8)
+		dz := warp.Noise3D(x+1.7, y+9.2, z+4.1)
+
+		return src.Noise3D(x+dx*strength, y+dy*strength, z+dz*strength)
+	}
+}
+
+// Tileable2D returns 2D noise that tiles seamlessly with the period w by h,
+// by sampling the 4D noise source on a torus. The radii of the torus are
+// chosen so that the noise has about the same scale as the 2D noise.
+func Tileable2D(src Noise4D, w, h float64) Noise2DFunc {
+	rx, ry := w/(2*math.Pi), h/(2*math.Pi)
+
+	return func(x, y float64) float64 {
+		s, t := x/rx, y/ry
+
+		return src.Noise4D(rx*math.Cos(s), rx*math.Sin(s), ry*math.Cos(t), ry*math.Sin(t))
+	}
+}
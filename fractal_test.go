@@ -0,0 +1,98 @@
+package gfx
+
+import "testing"
+
+func TestFractal(t *testing.T) {
+	pn := NewPerlinNoise(1234)
+
+	t.Run("Octave", func(t *testing.T) {
+		f := Fractal{Octaves: 1}.Fractal2D(pn)
+
+		if got, want := f.Noise2D(1.3, 2.7), pn.Noise2D(1.3, 2.7); got != want {
+			t.Fatalf("f.Noise2D(1.3, 2.7) = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Constant", func(t *testing.T) {
+		src := Noise2DFunc(func(x, y float64) float64 { return -0.5 })
+
+		for _, tc := range []struct {
+			ft   FractalType
+			want float64
+		}{
+			{FBMFractalType, -0.5},
+			{BillowFractalType, 0},
+			{TurbulenceFractalType, 0.5},
+			{HybridFractalType, (0.5 + 0.25*0.5) / 1.5},
+		} {
+			f := Fractal{Type: tc.ft, Octaves: 2}
+
+			if got := f.Fractal2D(src).Noise2D(0, 0); MathAbs(got-tc.want) > 1e-9 {
+				t.Fatalf("%d: f.Noise2D(0, 0) = %v, want %v", tc.ft, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		for _, tc := range []struct {
+			ft     FractalType
+			lo, hi float64
+		}{
+			{FBMFractalType, -1, 1},
+			{RidgedFractalType, 0, 1},
+			{BillowFractalType, -1, 1},
+			{TurbulenceFractalType, 0, 1},
+		} {
+			f2 := Fractal{Type: tc.ft}.Fractal2D(pn)
+			f3 := Fractal{Type: tc.ft, Octaves: 4}.Fractal3D(pn)
+			f4 := Fractal{Type: tc.ft, Octaves: 3}.Fractal4D(pn)
+
+			for y := -2.0; y < 2; y += 0.27 {
+				for x := -2.0; x < 2; x += 0.19 {
+					for _, v := range []float64{
+						f2.Noise2D(x, y),
+						f3.Noise3D(x, y, x*y),
+						f4.Noise4D(x, y, x+y, x-y),
+					} {
+						if v < tc.lo || v > tc.hi {
+							t.Fatalf("%d: noise at (%v, %v) = %v", tc.ft, x, y, v)
+						}
+					}
+				}
+			}
+		}
+	})
+}
+
+func TestDomainWarp(t *testing.T) {
+	pn := NewPerlinNoise(1234)
+	sn := NewSimplexNoise(1234)
+
+	if got, want := DomainWarp2D(pn, sn, 0).Noise2D(1.5, 2.5), pn.Noise2D(1.5, 2.5); got != want {
+		t.Fatalf("DomainWarp2D(0) = %v, want %v", got, want)
+	}
+
+	if got, want := DomainWarp3D(pn, sn, 0).Noise3D(1.5, 2.5, 0.5), pn.Noise3D(1.5, 2.5, 0.5); got != want {
+		t.Fatalf("DomainWarp3D(0) = %v, want %v", got, want)
+	}
+
+	if DomainWarp2D(pn, sn, 4).Noise2D(1.5, 2.5) == pn.Noise2D(1.5, 2.5) {
+		t.Fatalf("expected warped noise to differ")
+	}
+}
+
+func TestTileable2D(t *testing.T) {
+	f := Tileable2D(Fractal{Octaves: 3}.Fractal4D(NewSimplexNoise(1234)), 16, 8)
+
+	for y := 0.0; y < 8; y += 0.7 {
+		for x := 0.0; x < 16; x += 0.9 {
+			v := f.Noise2D(x, y)
+
+			for _, o := range []Vec{{16, 0}, {0, 8}, {-16, 24}} {
+				if got := f.Noise2D(x+o.X, y+o.Y); MathAbs(got-v) > 1e-4 {
+					t.Fatalf("f.Noise2D(%v, %v) = %v, want %v", x+o.X, y+o.Y, got, v)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+)
+
+// SpreadMode specifies how a gradient is continued outside of the [0, 1] range.
+type SpreadMode int
+
+const (
+	// PadSpreadMode continues with the color of the first or last stop.
+	PadSpreadMode SpreadMode = iota
+
+	// RepeatSpreadMode repeats the gradient.
+	RepeatSpreadMode
+
+	// ReflectSpreadMode repeats the gradient, reversing every other repetition.
+	ReflectSpreadMode
+)
+
+// Apply maps t into the range [0, 1] based on the spread mode.
+func (sm SpreadMode) Apply(t float64) float64 {
+	switch sm {
+	case RepeatSpreadMode:
+		return t - math.Floor(t)
+	case ReflectSpreadMode:
+		t = math.Abs(t)
+		t -= 2 * math.Floor(t/2)
+
+		if t > 1 {
+			t = 2 - t
+		}
+
+		return t
+	default:
+		return Clamp(t, 0, 1)
+	}
+}
+
+// ColorInterpolation specifies the color space used when interpolating between colors.
+type ColorInterpolation int
+
+const (
+	// SRGBColorInterpolation interpolates the (gamma encoded) sRGB components.
+	SRGBColorInterpolation ColorInterpolation = iota
+
+	// LinearRGBColorInterpolation interpolates the linear light RGB components.
+	LinearRGBColorInterpolation
+
+	// CIELabColorInterpolation interpolates in CIE-L*ab.
+	CIELabColorInterpolation
+)
+
+// Lerp performs linear interpolation between two colors in the color space.
+//
+// The sRGB and linear RGB interpolations are done using premultiplied alpha.
+func (ci ColorInterpolation) Lerp(c0, c1 color.Color, t float64) color.NRGBA64 {
+	v0, v1 := ci.components(c0), ci.components(c1)
+
+	var v [4]float64
+
+	for i := range v {
+		v[i] = Lerp(v0[i], v1[i], t)
+	}
+
+	return ci.color(v)
+}
+
+// components returns the color components in the color space.
+// (The last component is always alpha)
+func (ci ColorInterpolation) components(c color.Color) [4]float64 {
+	n := color.NRGBA64Model.Convert(c).(color.NRGBA64)
+
+	r, g, b, a := float64(n.R)/0xFFFF, float64(n.G)/0xFFFF, float64(n.B)/0xFFFF, float64(n.A)/0xFFFF
+
+	switch ci {
+	case LinearRGBColorInterpolation:
+		return [4]float64{sRGBToLinear(r) * a, sRGBToLinear(g) * a, sRGBToLinear(b) * a, a}
+	case CIELabColorInterpolation:
+		lab := ColorToXYZ(color.NRGBA64{n.R, n.G, n.B, 0xFFFF}).CIELab(XYZReference2.D65)
+
+		return [4]float64{lab.L, lab.A, lab.B, a}
+	default:
+		return [4]float64{r * a, g * a, b * a, a}
+	}
+}
+
+// color converts components in the color space into a color.NRGBA64.
+func (ci ColorInterpolation) color(v [4]float64) color.NRGBA64 {
+	var r, g, b float64
+
+	a := Clamp(v[3], 0, 1)
+
+	switch ci {
+	case CIELabColorInterpolation:
+		r, g, b = CIELab{v[0], v[1], v[2]}.XYZ(XYZReference2.D65).linearRGB()
+		r, g, b = linearToSRGB(Clamp(r, 0, 1)), linearToSRGB(Clamp(g, 0, 1)), linearToSRGB(Clamp(b, 0, 1))
+	default:
+		if a == 0 {
+			return color.NRGBA64{}
+		}
+
+		r, g, b = v[0]/a, v[1]/a, v[2]/a
+
+		if ci == LinearRGBColorInterpolation {
+			r, g, b = linearToSRGB(Clamp(r, 0, 1)), linearToSRGB(Clamp(g, 0, 1)), linearToSRGB(Clamp(b, 0, 1))
+		}
+	}
+
+	return color.NRGBA64{
+		uint16(math.Round(Clamp(r, 0, 1) * 0xFFFF)),
+		uint16(math.Round(Clamp(g, 0, 1) * 0xFFFF)),
+		uint16(math.Round(Clamp(b, 0, 1) * 0xFFFF)),
+		uint16(math.Round(a * 0xFFFF)),
+	}
+}
+
+// GradientStop is a color at an offset (range 0-1) in a gradient.
+type GradientStop struct {
+	Offset float64
+	Color  color.Color
+}
+
+// GradientStops is a slice of GradientStop.
+type GradientStops []GradientStop
+
+// At returns the color at the given offset, interpolated in the given color space.
+func (gs GradientStops) At(t float64, ci ColorInterpolation) color.Color {
+	n := len(gs)
+
+	switch {
+	case n == 0:
+		return color.Transparent
+	case t <= gs[0].Offset:
+		return gs[0].Color
+	case t >= gs[n-1].Offset:
+		return gs[n-1].Color
+	}
+
+	i := sort.Search(n, func(i int) bool { return gs[i].Offset > t })
+
+	s0, s1 := gs[i-1], gs[i]
+
+	return ci.Lerp(s0.Color, s1.Color, (t-s0.Offset)/(s1.Offset-s0.Offset))
+}
+
+type gradientKind int
+
+const (
+	linearGradient gradientKind = iota
+	radialGradient
+	focalRadialGradient
+	conicGradient
+)
+
+// Gradient is an image.Image of infinite size that paints a linear, radial,
+// focal-radial or conic gradient.
+//
+// The gradient geometry is transformed into image space by the Matrix.
+type Gradient struct {
+	Stops         GradientStops
+	Spread        SpreadMode
+	Interpolation ColorInterpolation
+	Matrix        Matrix
+
+	kind   gradientKind
+	p0, p1 Vec
+	radius float64
+}
+
+var _ image.Image = (*Gradient)(nil)
+
+// NewLinearGradient creates a gradient along the line from one vector to another.
+func NewLinearGradient(from, to Vec, stops ...GradientStop) *Gradient {
+	return newGradient(linearGradient, from, to, 0, stops)
+}
+
+// NewRadialGradient creates a gradient from the center out to the radius.
+func NewRadialGradient(center Vec, radius float64, stops ...GradientStop) *Gradient {
+	return newGradient(radialGradient, center, center, radius, stops)
+}
+
+// NewFocalRadialGradient creates a radial gradient where the first stop is
+// located at the focus point instead of at the center.
+//
+// A focus outside of the circle is moved to just inside of it.
+func NewFocalRadialGradient(center Vec, radius float64, focus Vec, stops ...GradientStop) *Gradient {
+	if d := center.To(focus); d.Len() > radius*0.999 {
+		focus = center.Add(d.Unit().Scaled(radius * 0.999))
+	}
+
+	return newGradient(focalRadialGradient, center, focus, radius, stops)
+}
+
+// NewConicGradient creates a gradient sweeping around the center,
+// starting at the given angle in radians.
+func NewConicGradient(center Vec, angle float64, stops ...GradientStop) *Gradient {
+	return newGradient(conicGradient, center, Unit(angle), 0, stops)
+}
+
+func newGradient(kind gradientKind, p0, p1 Vec, radius float64, stops GradientStops) *Gradient {
+	stops = append(GradientStops{}, stops...)
+
+	sort.SliceStable(stops, func(i, j int) bool {
+		return stops[i].Offset < stops[j].Offset
+	})
+
+	return &Gradient{
+		Stops:  stops,
+		Matrix: IM,
+		kind:   kind,
+		p0:     p0,
+		p1:     p1,
+		radius: radius,
+	}
+}
+
+// ColorModel returns the color model of the gradient.
+func (g *Gradient) ColorModel() color.Model {
+	return color.NRGBA64Model
+}
+
+// Bounds returns the (practically infinite) bounds of the gradient.
+func (g *Gradient) Bounds() image.Rectangle {
+	return image.Rectangle{image.Point{-1e9, -1e9}, image.Point{1e9, 1e9}}
+}
+
+// At returns the color at the center of the pixel at (x, y).
+func (g *Gradient) At(x, y int) color.Color {
+	return g.Color(IV(x, y).AddXY(0.5, 0.5))
+}
+
+// Color returns the color at the given vector.
+func (g *Gradient) Color(u Vec) color.Color {
+	return g.Stops.At(g.Spread.Apply(g.Offset(u)), g.Interpolation)
+}
+
+// Offset returns the (unspread) gradient offset at the given vector.
+func (g *Gradient) Offset(u Vec) float64 {
+	u = g.Matrix.Unproject(u)
+
+	switch g.kind {
+	case radialGradient:
+		if g.radius == 0 {
+			return 1
+		}
+
+		return u.Sub(g.p0).Len() / g.radius
+	case focalRadialGradient:
+		fu := g.p1.To(u)
+
+		l := fu.Len()
+		if l == 0 {
+			return 0
+		}
+
+		dir := fu.Scaled(1 / l)
+		cf := g.p0.To(g.p1)
+
+		b := dir.Dot(cf)
+		s := -b + math.Sqrt(b*b-(cf.Dot(cf)-g.radius*g.radius))
+
+		if s <= 0 {
+			return 1
+		}
+
+		return l / s
+	case conicGradient:
+		a := g.p1.angleTo(g.p0.To(u))
+
+		if a < 0 {
+			a += 2 * math.Pi
+		}
+
+		return a / (2 * math.Pi)
+	default:
+		d := g.p0.To(g.p1)
+
+		if l := d.Dot(d); l != 0 {
+			return g.p0.To(u).Dot(d) / l
+		}
+
+		return 0
+	}
+}
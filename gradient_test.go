@@ -0,0 +1,124 @@
+package gfx
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestSpreadModeApply(t *testing.T) {
+	for _, tc := range []struct {
+		sm   SpreadMode
+		t    float64
+		want float64
+	}{
+		{PadSpreadMode, -0.5, 0},
+		{PadSpreadMode, 1.5, 1},
+		{RepeatSpreadMode, 1.25, 0.25},
+		{RepeatSpreadMode, -0.25, 0.75},
+		{ReflectSpreadMode, 1.25, 0.75},
+		{ReflectSpreadMode, -0.25, 0.25},
+	} {
+		if got := tc.sm.Apply(tc.t); math.Abs(got-tc.want) > 1e-9 {
+			t.Fatalf("SpreadMode(%d).Apply(%v) = %v, want %v", tc.sm, tc.t, got, tc.want)
+		}
+	}
+}
+
+func TestLinearGradient(t *testing.T) {
+	g := NewLinearGradient(V(0, 0), V(10, 0),
+		GradientStop{1, ColorWhite},
+		GradientStop{0, ColorBlack},
+	)
+
+	dst := NewImage(10, 1)
+
+	Draw(dst, dst.Bounds(), g)
+
+	if got, want := dst.RGBAAt(0, 0).R, uint8(12); got != want {
+		t.Fatalf("dst.RGBAAt(0, 0).R = %d, want %d", got, want)
+	}
+
+	if got, want := dst.RGBAAt(9, 0).R, uint8(243); got != want {
+		t.Fatalf("dst.RGBAAt(9, 0).R = %d, want %d", got, want)
+	}
+
+	g.Matrix = IM.Moved(V(5, 0))
+
+	if got, want := g.Offset(V(10, 0)), 0.5; got != want {
+		t.Fatalf("g.Offset(V(10, 0)) = %v, want %v", got, want)
+	}
+}
+
+func TestRadialGradient(t *testing.T) {
+	g := NewRadialGradient(V(5, 5), 5, GradientStop{0, ColorRed}, GradientStop{1, ColorBlue})
+
+	if got, want := g.Offset(V(5, 8)), 0.6; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("g.Offset(V(5, 8)) = %v, want %v", got, want)
+	}
+
+	f := NewFocalRadialGradient(V(5, 5), 5, V(7, 5), GradientStop{0, ColorRed}, GradientStop{1, ColorBlue})
+
+	for _, tc := range []struct {
+		u    Vec
+		want float64
+	}{
+		{V(7, 5), 0},
+		{V(10, 5), 1},
+		{V(0, 5), 1},
+		{V(2, 5), 5.0 / 7},
+	} {
+		if got := f.Offset(tc.u); math.Abs(got-tc.want) > 1e-9 {
+			t.Fatalf("f.Offset(%v) = %v, want %v", tc.u, got, tc.want)
+		}
+	}
+}
+
+func TestConicGradient(t *testing.T) {
+	g := NewConicGradient(V(0, 0), 0, GradientStop{0, ColorRed}, GradientStop{1, ColorBlue})
+
+	for _, tc := range []struct {
+		u    Vec
+		want float64
+	}{
+		{V(1, 0), 0},
+		{V(0, 1), 0.25},
+		{V(-1, 0), 0.5},
+		{V(0, -1), 0.75},
+	} {
+		if got := g.Offset(tc.u); math.Abs(got-tc.want) > 1e-9 {
+			t.Fatalf("g.Offset(%v) = %v, want %v", tc.u, got, tc.want)
+		}
+	}
+}
+
+func TestColorInterpolationLerp(t *testing.T) {
+	for _, tc := range []struct {
+		ci   ColorInterpolation
+		want uint8
+	}{
+		{SRGBColorInterpolation, 128},
+		{LinearRGBColorInterpolation, 188},
+		{CIELabColorInterpolation, 119},
+	} {
+		c := color.NRGBAModel.Convert(tc.ci.Lerp(ColorBlack, ColorWhite, 0.5)).(color.NRGBA)
+
+		if got := c.R; got != tc.want {
+			t.Fatalf("ColorInterpolation(%d).Lerp(...).R = %d, want %d", tc.ci, got, tc.want)
+		}
+	}
+
+	c := color.NRGBAModel.Convert(CIELabColorInterpolation.Lerp(ColorRed, ColorRed, 0.5)).(color.NRGBA)
+
+	if c != ColorRed {
+		t.Fatalf("CIELab round trip = %v, want %v", c, ColorRed)
+	}
+}
+
+func TestGradientStopsAt(t *testing.T) {
+	var empty GradientStops
+
+	if got, want := empty.At(0.5, SRGBColorInterpolation), color.Transparent; got != want {
+		t.Fatalf("empty.At(0.5) = %v, want %v", got, want)
+	}
+}
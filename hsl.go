@@ -7,50 +7,43 @@ import (
 
 // ColorToHSL converts a color into HSL.
 func ColorToHSL(c color.Color) HSL {
-	var (
-		r, g, b  = floatRGB(c)
-		min, max = minRGB(r, g, b), maxRGB(r, g, b)
-		h, s, l  = (max + min) / 2.0, (max + min) / 2.0, (max + min) / 2.0
-	)
+	r, g, b, a := floatNRGBA(c)
 
-	if max == min {
-		h, s = 0, 0 // achromatic
-	} else {
-		d := max - min
-
-		if l > 0.4 {
-			s = d / (2 - max - min)
-		} else {
-			s = d / (max + min)
-		}
-
-		switch max {
-		case r:
-			if g < b {
-				h = (g-b)/d + 6
-			} else {
-				h = (g-b)/d + 0
-			}
-		case g:
-			h = (b-r)/d + 2
-		case b:
-			h = (r-g)/d + 4
-		}
-
-		h /= 6
-	}
+	h, s, l := rgbToHSL(r, g, b)
 
-	return HSL{h * 360, s, l}
+	return HSL{h, s, l, a}
 }
 
 // HSL is the hue, saturation and lightness color representation.
 // - Hue        [0,360]
 // - Saturation [0,1]
 // - Lightness  [0,1]
+// - Alpha      [0,1]
+//
+// Like color.NRGBA, a zero Alpha is fully transparent. Literals without an
+// Alpha, such as HSL{Hue: 0, Saturation: 1, Lightness: 0.5}, were opaque before
+// the Alpha was added and need Alpha: 1 (or NewHSL) to stay opaque.
 type HSL struct {
 	Hue        float64
 	Saturation float64
 	Lightness  float64
+	Alpha      float64
+}
+
+// NewHSL creates an opaque HSL color from the hue, saturation and lightness.
+func NewHSL(h, s, l float64) HSL {
+	return HSL{h, s, l, 1}
+}
+
+// HSLModel is the color model for HSL colors.
+var HSLModel = color.ModelFunc(hslModel)
+
+func hslModel(c color.Color) color.Color {
+	if c, ok := c.(HSL); ok {
+		return c
+	}
+
+	return ColorToHSL(c)
 }
 
 // Components in HSL.
@@ -58,34 +51,87 @@ func (hsl HSL) Components() (h, s, l float64) {
 	return hsl.Hue, hsl.Saturation, hsl.Lightness
 }
 
-// RGBA converts a HSL color value to color.RGBA.
-func (hsl HSL) RGBA() color.RGBA {
-	h, s, l := hsl.Components()
+// RGBA implements the color.Color interface.
+func (hsl HSL) RGBA() (r, g, b, a uint32) {
+	fr, fg, fb := hslToRGB(hsl.Components())
 
-	var r, g, b float64
+	return floatRGBA64(fr, fg, fb, hsl.Alpha).RGBA()
+}
 
-	switch s {
-	case 0:
-		r, g, b = l, l, l // achromatic
-	default:
-		var q = l + s - l*s
+// rgbToHSL converts R, G and B in the range [0, 1] into
+// the hue in degrees, and the saturation and lightness.
+func rgbToHSL(r, g, b float64) (h, s, l float64) {
+	min, max := minRGB(r, g, b), maxRGB(r, g, b)
 
-		if l < 0.5 {
-			q = l * (1 + s)
-		}
+	l = (max + min) / 2
 
-		var p = 2*l - q
+	if max == min {
+		return 0, 0, l // achromatic
+	}
+
+	d := max - min
 
-		r = hue2rgb(p, q, h+1.0/3)
-		g = hue2rgb(p, q, h)
-		b = hue2rgb(p, q, h-1.0/3)
+	if l > 0.5 {
+		s = d / (2 - max - min)
+	} else {
+		s = d / (max + min)
 	}
 
-	cr := Clamp(math.Round(r*255), 0, 255)
-	cg := Clamp(math.Round(g*255), 0, 255)
-	cb := Clamp(math.Round(b*255), 0, 255)
+	return rgbHue(r, g, b, max, d), s, l
+}
+
+// hslToRGB converts the hue in degrees, and the saturation
+// and lightness into R, G and B in the range [0, 1].
+func hslToRGB(h, s, l float64) (r, g, b float64) {
+	s, l = Clamp(s, 0, 1), Clamp(l, 0, 1)
+
+	if s == 0 {
+		return l, l, l // achromatic
+	}
+
+	h = hueDegrees(h) / 360
+
+	q := l + s - l*s
 
-	return ColorRGBA(uint8(cr), uint8(cg), uint8(cb), 255)
+	if l < 0.5 {
+		q = l * (1 + s)
+	}
+
+	p := 2*l - q
+
+	return hue2rgb(p, q, h+1.0/3), hue2rgb(p, q, h), hue2rgb(p, q, h-1.0/3)
+}
+
+// rgbHue returns the hue in degrees of R, G and B,
+// given the largest component and the chroma d.
+func rgbHue(r, g, b, max, d float64) float64 {
+	var h float64
+
+	switch max {
+	case r:
+		h = (g - b) / d
+
+		if g < b {
+			h += 6
+		}
+	case g:
+		h = (b-r)/d + 2
+	case b:
+		h = (r-g)/d + 4
+	}
+
+	return h * 60
+}
+
+// hueDegrees returns the hue wrapped into the range [0, 360).
+func hueDegrees(h float64) float64 {
+	h = math.Mod(h, 360)
+
+	if h < 0 {
+		h += 360
+	}
+
+	return h
 }
 
 func hue2rgb(p, q, t float64) float64 {
@@ -118,6 +164,31 @@ func floatRGB(c color.Color) (r, g, b float64) {
 	return float64(cR) / 0xFFFF, float64(cG) / 0xFFFF, float64(cB) / 0xFFFF
 }
 
+// floatNRGBA returns the non-premultiplied R, G and B, and the alpha of c in the range [0, 1].
+func floatNRGBA(c color.Color) (r, g, b, a float64) {
+	cR, cG, cB, cA := c.RGBA()
+
+	if cA == 0 {
+		return 0, 0, 0, 0
+	}
+
+	a = float64(cA)
+
+	return float64(cR) / a, float64(cG) / a, float64(cB) / a, a / 0xFFFF
+}
+
+// floatRGBA64 converts non-premultiplied R, G and B, and the alpha
+// in the range [0, 1] into a premultiplied color.RGBA64.
+func floatRGBA64(r, g, b, a float64) color.RGBA64 {
+	a = Clamp(a, 0, 1)
+
+	c := func(v float64) uint16 {
+		return uint16(math.Round(Clamp(v, 0, 1) * a * 0xFFFF))
+	}
+
+	return color.RGBA64{c(r), c(g), c(b), uint16(math.Round(a * 0xFFFF))}
+}
+
 func maxRGB(r, g, b float64) float64 {
 	return MathMax(r, MathMax(g, b))
 }
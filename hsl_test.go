@@ -0,0 +1,69 @@
+package gfx
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestColorToHSL(t *testing.T) {
+	for _, tc := range []struct {
+		c    color.Color
+		want HSL
+	}{
+		{ColorNRGBA(255, 0, 0, 255), HSL{0, 1, 0.5, 1}},
+		{ColorNRGBA(0, 255, 0, 255), HSL{120, 1, 0.5, 1}},
+		{ColorNRGBA(0, 0, 255, 128), HSL{240, 1, 0.5, 128.0 / 255}},
+		{ColorNRGBA(255, 255, 255, 255), HSL{0, 0, 1, 1}},
+		{ColorNRGBA(191, 64, 191, 255), HSL{300, 0.4980, 0.5, 1}},
+	} {
+		got := ColorToHSL(tc.c)
+
+		if math.Abs(got.Hue-tc.want.Hue) > 1e-3 ||
+			math.Abs(got.Saturation-tc.want.Saturation) > 1e-3 ||
+			math.Abs(got.Lightness-tc.want.Lightness) > 1e-2 ||
+			math.Abs(got.Alpha-tc.want.Alpha) > 1e-9 {
+			t.Fatalf("ColorToHSL(%v) = %+v, want %+v", tc.c, got, tc.want)
+		}
+	}
+}
+
+func TestHSLRGBA(t *testing.T) {
+	for _, tc := range []struct {
+		hsl  HSL
+		want color.NRGBA
+	}{
+		{HSL{0, 1, 0.5, 1}, color.NRGBA{255, 0, 0, 255}},
+		{HSL{360, 1, 0.5, 1}, color.NRGBA{255, 0, 0, 255}},
+		{HSL{-120, 1, 0.5, 1}, color.NRGBA{0, 0, 255, 255}},
+		{HSL{60, 1, 0.5, 1}, color.NRGBA{255, 255, 0, 255}},
+		{HSL{120, 0, 1, 0.5}, color.NRGBA{255, 255, 255, 128}},
+		{HSL{120, 1, 0.5, 0}, color.NRGBA{}},
+	} {
+		if got := color.NRGBAModel.Convert(tc.hsl).(color.NRGBA); got != tc.want {
+			t.Fatalf("%+v = %v, want %v", tc.hsl, got, tc.want)
+		}
+	}
+}
+
+func TestHSLModel(t *testing.T) {
+	testRoundTrip(t, HSLModel)
+}
+
+func TestNewHSL(t *testing.T) {
+	red := color.NRGBA{255, 0, 0, 255}
+
+	// Keyed literals without an Alpha are transparent, see the HSL docs.
+	for _, tc := range []struct {
+		hsl  HSL
+		want color.NRGBA
+	}{
+		{NewHSL(0, 1, 0.5), red},
+		{HSL{Hue: 0, Saturation: 1, Lightness: 0.5, Alpha: 1}, red},
+		{HSL{Hue: 0, Saturation: 1, Lightness: 0.5}, color.NRGBA{}},
+	} {
+		if got := color.NRGBAModel.Convert(tc.hsl).(color.NRGBA); got != tc.want {
+			t.Fatalf("%+v = %v, want %v", tc.hsl, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package gfx
+
+import (
+	"image/color"
+	"math"
+)
+
+// HSLuv is a human friendly alternative to HSL, based on CIE-LCh(uv)
+// with the chroma scaled to the maximum chroma within the sRGB gamut.
+// https://www.hsluv.org/
+//   - Hue        [0,360]
+//   - Saturation [0,1]
+//   - Lightness  [0,1]
+//   - Alpha      [0,1]
+type HSLuv struct {
+	Hue        float64
+	Saturation float64
+	Lightness  float64
+	Alpha      float64
+}
+
+// HSLuvModel is the color model for the HSLuv color space.
+var HSLuvModel = color.ModelFunc(hsluvModel)
+
+func hsluvModel(c color.Color) color.Color {
+	if c, ok := c.(HSLuv); ok {
+		return c
+	}
+
+	r, g, b, a := linearNRGBA(c)
+
+	h := linearRGBToXYZ(r, g, b).HSLuv()
+	h.Alpha = a
+
+	return h
+}
+
+// CIE-LUV constants, with the reference white of the sRGB primaries.
+const (
+	luvKappa   = 903.2962962962963
+	luvEpsilon = 0.0088564516790356308
+)
+
+var (
+	luvWhite         = linearRGBToXYZ(1, 1, 1)
+	luvRefU, luvRefV = luvUV(luvWhite)
+)
+
+// HSLuv converts from XYZ (D65/2°) to an opaque HSLuv color.
+func (xyz XYZ) HSLuv() HSLuv {
+	l, c, h := xyz.lchUV()
+
+	if l > 99.9999999 || l < 1e-8 {
+		return HSLuv{h, 0, Clamp(l/100, 0, 1), 1}
+	}
+
+	return HSLuv{h, Clamp(c/hsluvMaxChroma(l, h), 0, 1), l / 100, 1}
+}
+
+// RGBA implements the color.Color interface.
+func (c HSLuv) RGBA() (r, g, b, a uint32) {
+	lr, lg, lb := c.XYZ().linearRGB()
+
+	return colorFromLinearRGB(lr, lg, lb, c.Alpha).RGBA()
+}
+
+// XYZ converts from HSLuv to XYZ (D65/2°).
+func (c HSLuv) XYZ() XYZ {
+	l := Clamp(c.Lightness, 0, 1) * 100
+
+	if l > 99.9999999 {
+		return luvWhite
+	}
+
+	if l < 1e-8 {
+		return XYZ{}
+	}
+
+	ch := hsluvMaxChroma(l, c.Hue) * Clamp(c.Saturation, 0, 1)
+	h := c.Hue * math.Pi / 180
+
+	return luvToXYZ(l, ch*math.Cos(h), ch*math.Sin(h))
+}
+
+// lchUV converts from XYZ to CIE-LCh(uv).
+func (xyz XYZ) lchUV() (l, c, h float64) {
+	u, v := luvUV(xyz)
+
+	l = luvL(xyz.Y / 100)
+
+	if l == 0 || math.IsNaN(u) {
+		return 0, 0, 0
+	}
+
+	u, v = 13*l*(u-luvRefU), 13*l*(v-luvRefV)
+
+	c = math.Hypot(u, v)
+
+	if c < 1e-8 {
+		return l, 0, 0
+	}
+
+	h = math.Atan2(v, u) * 180 / math.Pi
+
+	if h < 0 {
+		h += 360
+	}
+
+	return l, c, h
+}
+
+// luvUV returns the u′ and v′ chromaticity coordinates of the XYZ color.
+func luvUV(xyz XYZ) (u, v float64) {
+	d := xyz.X + 15*xyz.Y + 3*xyz.Z
+
+	return 4 * xyz.X / d, 9 * xyz.Y / d
+}
+
+// luvL converts the relative luminance y (range [0, 1]) into CIE-L*.
+func luvL(y float64) float64 {
+	if y <= luvEpsilon {
+		return y * luvKappa
+	}
+
+	return 116*math.Cbrt(y) - 16
+}
+
+// luvY converts CIE-L* into the relative luminance (range [0, 1]).
+func luvY(l float64) float64 {
+	if l <= 8 {
+		return l / luvKappa
+	}
+
+	return math.Pow((l+16)/116, 3)
+}
+
+// luvToXYZ converts from CIE-L*uv to XYZ (D65/2°).
+func luvToXYZ(l, u, v float64) XYZ {
+	y := luvY(l)
+
+	vu := u/(13*l) + luvRefU
+	vv := v/(13*l) + luvRefV
+
+	x := 9 * y * vu / (4 * vv)
+	z := y * (12 - 3*vu - 20*vv) / (4 * vv)
+
+	return XYZ{x * 100, y * 100, z * 100}
+}
+
+// hsluvMaxChroma returns the maximum CIE-LCh(uv) chroma within the
+// sRGB gamut for the lightness l (range [0, 100]) and hue h in degrees.
+//
+// For a given lightness, each of the R, G and B components reaching 0 or 1
+// is a line in the uv plane, and the maximum chroma is the distance to the
+// closest line in the direction of the hue.
+func hsluvMaxChroma(l, h float64) float64 {
+	hr := h * math.Pi / 180
+	sin, cos := math.Sin(hr), math.Cos(hr)
+
+	y := luvY(l)
+
+	min := math.Inf(1)
+
+	for _, m := range xyzToLinearRGB {
+		for t := 0.0; t <= 1; t++ {
+			// The component is t where a*u′ + b*v′ + k = 0
+			a := y * (9*m[0] - 3*m[2])
+			b := y*(4*m[1]-20*m[2]) - 4*t
+			k := 12 * y * m[2]
+
+			if d := a*cos + b*sin; d != 0 {
+				if c := -(a*luvRefU + b*luvRefV + k) * 13 * l / d; c >= 0 && c < min {
+					min = c
+				}
+			}
+		}
+	}
+
+	return min
+}
@@ -0,0 +1,43 @@
+package gfx
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestHSLuv(t *testing.T) {
+	h := HSLuvModel.Convert(ColorNRGBA(255, 0, 0, 255)).(HSLuv)
+
+	if math.Abs(h.Hue-12.17) > 0.01 || math.Abs(h.Saturation-1) > 1e-4 || math.Abs(h.Lightness-0.5323) > 1e-4 {
+		t.Fatalf("HSLuv = %+v", h)
+	}
+
+	for _, tc := range []struct {
+		c    HSLuv
+		want color.NRGBA
+	}{
+		{HSLuv{0, 0, 1, 1}, color.NRGBA{255, 255, 255, 255}},
+		{HSLuv{120, 1, 0, 1}, color.NRGBA{0, 0, 0, 255}},
+		{HSLuv{0, 0, 0.5, 0.5}, color.NRGBA{119, 119, 119, 128}},
+	} {
+		if got := color.NRGBAModel.Convert(tc.c); got != tc.want {
+			t.Fatalf("%+v = %v, want %v", tc.c, got, tc.want)
+		}
+	}
+
+	// Every fully saturated HSLuv color is within the sRGB gamut.
+	for hue := 0.0; hue < 360; hue += 7.5 {
+		for l := 0.05; l < 1; l += 0.1 {
+			r, g, b := HSLuv{hue, 1, l, 1}.XYZ().linearRGB()
+
+			if !linearRGBInGamut(r, g, b) {
+				t.Fatalf("HSLuv{%v, 1, %v} = %v, %v, %v", hue, l, r, g, b)
+			}
+		}
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		testRoundTrip(t, HSLuvModel)
+	})
+}
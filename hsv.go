@@ -7,46 +7,43 @@ import (
 
 // ColorToHSV converts a color into HSV.
 func ColorToHSV(c color.Color) HSV {
-	var (
-		r, g, b    = floatRGB(c)
-		min, max   = minRGB(r, g, b), maxRGB(r, g, b)
-		h, s, v, d = max, max, max, max - min
-	)
-
-	if max == 0 {
-		s = 0
-	} else {
-		s = d / max
-	}
+	r, g, b, a := floatNRGBA(c)
 
-	if max == min {
-		h = 0 // achromatic
-	} else {
-		switch max {
-		case r:
-			if g < b {
-				h = (g-b)/d + 6.0
-			} else {
-				h = (g - b) / d
-			}
-		case g:
-			h = (b-r)/d + 2.0
-		case b:
-			h = (r-g)/d + 4.0
-		}
-	}
+	h, s, v := rgbToHSV(r, g, b)
 
-	return HSV{h, s * 100.0, v * 100.0}
+	return HSV{h, s, v, a}
 }
 
 // HSV is the hue, saturation and value color representation.
 // - Hue        [0,360]
 // - Saturation [0,1]
 // - Value      [0,1]
+// - Alpha      [0,1]
+//
+// Like color.NRGBA, a zero Alpha is fully transparent. Literals without an
+// Alpha, such as HSV{Hue: 0, Saturation: 1, Value: 1}, were opaque before
+// the Alpha was added and need Alpha: 1 (or NewHSV) to stay opaque.
 type HSV struct {
 	Hue        float64
 	Saturation float64
 	Value      float64
+	Alpha      float64
+}
+
+// NewHSV creates an opaque HSV color from the hue, saturation and value.
+func NewHSV(h, s, v float64) HSV {
+	return HSV{h, s, v, 1}
+}
+
+// HSVModel is the color model for HSV colors.
+var HSVModel = color.ModelFunc(hsvModel)
+
+func hsvModel(c color.Color) color.Color {
+	if c, ok := c.(HSV); ok {
+		return c
+	}
+
+	return ColorToHSV(c)
 }
 
 // Components in HSV.
@@ -54,49 +51,52 @@ func (hsv HSV) Components() (h, s, v float64) {
 	return hsv.Hue, hsv.Saturation, hsv.Value
 }
 
-// RGBA converts a HSV color value to color.RGBA.
-func (hsv HSV) RGBA() color.RGBA {
-	h, s, v := hsv.Components()
+// RGBA implements the color.Color interface.
+func (hsv HSV) RGBA() (r, g, b, a uint32) {
+	fr, fg, fb := hsvToRGB(hsv.Components())
+
+	return floatRGBA64(fr, fg, fb, hsv.Alpha).RGBA()
+}
+
+// rgbToHSV converts R, G and B in the range [0, 1] into
+// the hue in degrees, and the saturation and value.
+func rgbToHSV(r, g, b float64) (h, s, v float64) {
+	min, max := minRGB(r, g, b), maxRGB(r, g, b)
+
+	if max == min {
+		return 0, 0, max // achromatic
+	}
+
+	d := max - min
+
+	return rgbHue(r, g, b, max, d), d / max, max
+}
 
-	hprime := h / 60.0
+// hsvToRGB converts the hue in degrees, and the saturation
+// and value into R, G and B in the range [0, 1].
+func hsvToRGB(h, s, v float64) (r, g, b float64) {
+	s, v = Clamp(s, 0, 1), Clamp(v, 0, 1)
 
-	var r, g, b float64
+	hprime := hueDegrees(h) / 60
 
 	c := v * s
-	x := c * math.Abs(math.Remainder(hprime, 2))
+	x := c * (1 - math.Abs(math.Mod(hprime, 2)-1))
 	m := v - c
 
 	switch {
-	case hprime >= 0 && hprime < 1:
-		r = c
-		g = x
-		b = 0
-	case hprime >= 1 && hprime < 2:
-		r = x
-		g = c
-		b = 0
-	case hprime >= 2 && hprime < 3:
-		r = 0
-		g = c
-		b = x
-	case hprime >= 3 && hprime < 4:
-		r = 0
-		g = x
-		b = c
-	case hprime >= 4 && hprime < 5:
-		r = x
-		g = 0
-		b = c
-	case hprime >= 5 && hprime < 6:
-		r = c
-		g = 0
-		b = x
+	case hprime < 1:
+		r, g, b = c, x, 0
+	case hprime < 2:
+		r, g, b = x, c, 0
+	case hprime < 3:
+		r, g, b = 0, c, x
+	case hprime < 4:
+		r, g, b = 0, x, c
+	case hprime < 5:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
 	}
 
-	return ColorRGBA(
-		uint8(math.Round((r+m)*255)),
-		uint8(math.Round((g+m)*255)),
-		uint8(math.Round((b+m)*255)),
-		255,
-	)
+	return r + m, g + m, b + m
 }
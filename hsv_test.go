@@ -0,0 +1,68 @@
+package gfx
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestColorToHSV(t *testing.T) {
+	for _, tc := range []struct {
+		c    color.Color
+		want HSV
+	}{
+		{ColorNRGBA(255, 0, 0, 255), HSV{0, 1, 1, 1}},
+		{ColorNRGBA(0, 128, 0, 255), HSV{120, 1, 128.0 / 255, 1}},
+		{ColorNRGBA(0, 0, 255, 128), HSV{240, 1, 1, 128.0 / 255}},
+		{ColorNRGBA(255, 255, 255, 255), HSV{0, 0, 1, 1}},
+		{ColorNRGBA(191, 191, 0, 255), HSV{60, 1, 191.0 / 255, 1}},
+	} {
+		got := ColorToHSV(tc.c)
+
+		if math.Abs(got.Hue-tc.want.Hue) > 1e-9 ||
+			math.Abs(got.Saturation-tc.want.Saturation) > 1e-9 ||
+			math.Abs(got.Value-tc.want.Value) > 1e-9 ||
+			math.Abs(got.Alpha-tc.want.Alpha) > 1e-9 {
+			t.Fatalf("ColorToHSV(%v) = %+v, want %+v", tc.c, got, tc.want)
+		}
+	}
+}
+
+func TestHSVRGBA(t *testing.T) {
+	for _, tc := range []struct {
+		hsv  HSV
+		want color.NRGBA
+	}{
+		{HSV{0, 1, 1, 1}, color.NRGBA{255, 0, 0, 255}},
+		{HSV{360, 1, 1, 1}, color.NRGBA{255, 0, 0, 255}},
+		{HSV{180, 1, 0.5, 1}, color.NRGBA{0, 128, 128, 255}},
+		{HSV{300, 0.5, 1, 1}, color.NRGBA{255, 128, 255, 255}},
+		{HSV{120, 1, 1, 0}, color.NRGBA{}},
+	} {
+		if got := color.NRGBAModel.Convert(tc.hsv).(color.NRGBA); got != tc.want {
+			t.Fatalf("%+v = %v, want %v", tc.hsv, got, tc.want)
+		}
+	}
+}
+
+func TestHSVModel(t *testing.T) {
+	testRoundTrip(t, HSVModel)
+}
+
+func TestNewHSV(t *testing.T) {
+	red := color.NRGBA{255, 0, 0, 255}
+
+	// Keyed literals without an Alpha are transparent, see the HSV docs.
+	for _, tc := range []struct {
+		hsv  HSV
+		want color.NRGBA
+	}{
+		{NewHSV(0, 1, 1), red},
+		{HSV{Hue: 0, Saturation: 1, Value: 1, Alpha: 1}, red},
+		{HSV{Hue: 0, Saturation: 1, Value: 1}, color.NRGBA{}},
+	} {
+		if got := color.NRGBAModel.Convert(tc.hsv).(color.NRGBA); got != tc.want {
+			t.Fatalf("%+v = %v, want %v", tc.hsv, got, tc.want)
+		}
+	}
+}
@@ -78,6 +78,9 @@ const (
 
 	// RoundEndShape is a circular end shape.
 	RoundEndShape
+
+	// SquareEndShape is a square end shape, extending the line by half its thickness.
+	SquareEndShape
 )
 
 // NewIMDraw creates a new empty IMDraw. An optional Picture can be used to draw with a Picture.
@@ -529,6 +532,12 @@ func (imd *IMDraw) polyline(thickness float64, closed bool) {
 		case RoundEndShape:
 			imd.pushPt(points[j].pos, points[j])
 			imd.fillEllipseArc(V(thickness/2, thickness/2), ijNormal.Angle(), ijNormal.Angle()+math.Pi)
+		case SquareEndShape:
+			imd.pushPt(points[j].pos.Add(ijNormal), points[j])
+			imd.pushPt(points[j].pos.Sub(ijNormal), points[j])
+			imd.pushPt(points[j].pos.Sub(ijNormal).Add(ijNormal.Normal()), points[j])
+			imd.pushPt(points[j].pos.Add(ijNormal).Add(ijNormal.Normal()), points[j])
+			imd.fillPolygon()
 		}
 	}
 
@@ -607,6 +616,12 @@ func (imd *IMDraw) polyline(thickness float64, closed bool) {
 		case RoundEndShape:
 			imd.pushPt(points[j].pos, points[j])
 			imd.fillEllipseArc(V(thickness/2, thickness/2), ijNormal.Angle(), ijNormal.Angle()-math.Pi)
+		case SquareEndShape:
+			imd.pushPt(points[j].pos.Add(ijNormal), points[j])
+			imd.pushPt(points[j].pos.Sub(ijNormal), points[j])
+			imd.pushPt(points[j].pos.Sub(ijNormal).Sub(ijNormal.Normal()), points[j])
+			imd.pushPt(points[j].pos.Add(ijNormal).Sub(ijNormal.Normal()), points[j])
+			imd.fillPolygon()
 		}
 	}
 
@@ -0,0 +1,282 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// MarchingSquares holds a scalar field sampled on a grid over a Rect,
+// used to extract iso-lines and iso-bands using the marching squares algorithm.
+type MarchingSquares struct {
+	r          Rect
+	cols, rows int
+	values     []float64
+}
+
+// NewMarchingSquares samples fn over the Rect on a grid of cols×rows cells.
+func NewMarchingSquares(fn func(Vec) float64, r Rect, cols, rows int) *MarchingSquares {
+	cols, rows = IntMax(1, cols), IntMax(1, rows)
+
+	ms := &MarchingSquares{r: r, cols: cols, rows: rows, values: make([]float64, (cols+1)*(rows+1))}
+
+	for j := 0; j <= rows; j++ {
+		for i := 0; i <= cols; i++ {
+			ms.values[j*(cols+1)+i] = fn(ms.position(i, j))
+		}
+	}
+
+	return ms
+}
+
+// NewImageMarchingSquares samples the gray level (range 0-1) of the
+// src image at the center of each pixel.
+func NewImageMarchingSquares(src image.Image) *MarchingSquares {
+	b := src.Bounds()
+
+	r := R(float64(b.Min.X)+0.5, float64(b.Min.Y)+0.5, float64(b.Max.X)-0.5, float64(b.Max.Y)-0.5)
+
+	return NewMarchingSquares(func(u Vec) float64 {
+		g := color.Gray16Model.Convert(src.At(int(u.X), int(u.Y))).(color.Gray16)
+
+		return float64(g.Y) / 0xFFFF
+	}, r, b.Dx()-1, b.Dy()-1)
+}
+
+// position returns the position of the grid point i, j.
+func (ms *MarchingSquares) position(i, j int) Vec {
+	i, j = IntClamp(i, 0, ms.cols), IntClamp(j, 0, ms.rows)
+
+	return V(
+		Lerp(ms.r.Min.X, ms.r.Max.X, float64(i)/float64(ms.cols)),
+		Lerp(ms.r.Min.Y, ms.r.Max.Y, float64(j)/float64(ms.rows)),
+	)
+}
+
+// value returns the value at the grid point i, j,
+// or negative infinity outside of the grid.
+func (ms *MarchingSquares) value(i, j int) float64 {
+	if i < 0 || j < 0 || i > ms.cols || j > ms.rows {
+		return math.Inf(-1)
+	}
+
+	return ms.values[j*(ms.cols+1)+i]
+}
+
+// IsoLines returns the lines where the field is equal to iso.
+//
+// Closed lines end with the same point they start with.
+func (ms *MarchingSquares) IsoLines(iso float64) Polyline {
+	var pl Polyline
+
+	for _, c := range ms.trace(iso, false) {
+		if c.closed && len(c.pts) > 0 {
+			c.pts = append(c.pts, c.pts[0])
+		}
+
+		pl = append(pl, c.pts)
+	}
+
+	return pl
+}
+
+// Contours returns the closed polygons around the areas where the field
+// is greater than or equal to iso, clipped to the Rect.
+//
+// The outer contours are clockwise and holes are counter-clockwise
+// (with the Y axis pointing down), so they can be filled with either FillRule.
+func (ms *MarchingSquares) Contours(iso float64) Polyline {
+	var pl Polyline
+
+	for _, c := range ms.trace(iso, true) {
+		pl = append(pl, c.pts)
+	}
+
+	return pl
+}
+
+// IsoBand returns the closed polygons around the areas where the field
+// is in the range [lo, hi), clipped to the Rect.
+//
+// The polygons are meant to be filled using the EvenOddFillRule.
+func (ms *MarchingSquares) IsoBand(lo, hi float64) Polyline {
+	return append(ms.Contours(lo), ms.Contours(hi)...)
+}
+
+// marchingEdge identifies the edge starting at grid point i, j,
+// either horizontal or vertical.
+type marchingEdge struct {
+	i, j     int
+	vertical bool
+}
+
+type marchingSegment struct {
+	from, to marchingEdge
+}
+
+type marchingContour struct {
+	pts    Polygon
+	closed bool
+}
+
+// trace runs marching squares for the iso value. When padded is true, the
+// grid is surrounded by values below iso, which makes all contours closed.
+func (ms *MarchingSquares) trace(iso float64, padded bool) []marchingContour {
+	// The range of cells, including the padding.
+	first, last := 0, 0
+
+	if padded {
+		first, last = -1, 1
+	}
+
+	points := map[marchingEdge]Vec{}
+
+	// crossing returns the interpolated point where the iso value crosses the edge.
+	crossing := func(e marchingEdge) Vec {
+		if u, ok := points[e]; ok {
+			return u
+		}
+
+		i1, j1 := e.i+1, e.j
+
+		if e.vertical {
+			i1, j1 = e.i, e.j+1
+		}
+
+		v0, v1 := ms.value(e.i, e.j), ms.value(i1, j1)
+
+		var t float64
+
+		switch {
+		case math.IsInf(v0, -1):
+			t = 1
+		case math.IsInf(v1, -1):
+			t = 0
+		default:
+			t = (iso - v0) / (v1 - v0)
+		}
+
+		u := ms.position(e.i, e.j).Lerp(ms.position(i1, j1), t)
+
+		points[e] = u
+
+		return u
+	}
+
+	var segments []marchingSegment
+
+	for j := first; j < ms.rows+last; j++ {
+		for i := first; i < ms.cols+last; i++ {
+			// The corners and edges of the cell in clockwise order.
+			var (
+				corners = [4]float64{ms.value(i, j), ms.value(i+1, j), ms.value(i+1, j+1), ms.value(i, j+1)}
+				edges   = [4]marchingEdge{{i, j, false}, {i + 1, j, true}, {i, j + 1, false}, {i, j, true}}
+			)
+
+			// Entries (outside to inside) and exits (inside to outside) in clockwise order.
+			var (
+				crossings [4]marchingEdge
+				entry     [4]bool
+				n         int
+			)
+
+			for k := 0; k < 4; k++ {
+				a, b := corners[k] >= iso, corners[(k+1)%4] >= iso
+
+				if a != b {
+					crossings[n], entry[n] = edges[k], b
+					n++
+				}
+			}
+
+			if n == 0 {
+				continue
+			}
+
+			// Rotate so that the first crossing is an entry.
+			if !entry[0] {
+				copy(crossings[:n], append(crossings[1:n:n], crossings[0]))
+			}
+
+			if n == 2 {
+				segments = append(segments, marchingSegment{crossings[1], crossings[0]})
+				continue
+			}
+
+			// Saddle, disambiguated using the average of the corners.
+			center := (corners[0] + corners[1] + corners[2] + corners[3]) / 4
+
+			if center >= iso {
+				segments = append(segments,
+					marchingSegment{crossings[1], crossings[2]},
+					marchingSegment{crossings[3], crossings[0]},
+				)
+			} else {
+				segments = append(segments,
+					marchingSegment{crossings[1], crossings[0]},
+					marchingSegment{crossings[3], crossings[2]},
+				)
+			}
+		}
+	}
+
+	// Chain the segments into contours.
+	next := map[marchingEdge]int{}
+	ends := map[marchingEdge]bool{}
+
+	for k, s := range segments {
+		next[s.from] = k
+		ends[s.to] = true
+	}
+
+	used := make([]bool, len(segments))
+
+	var contours []marchingContour
+
+	follow := func(k int) marchingContour {
+		var c marchingContour
+
+		start := segments[k].from
+
+		c.pts = append(c.pts, crossing(start))
+
+		for {
+			used[k] = true
+
+			to := segments[k].to
+
+			if to == start {
+				c.closed = true
+				break
+			}
+
+			c.pts = append(c.pts, crossing(to))
+
+			nk, ok := next[to]
+			if !ok || used[nk] {
+				break
+			}
+
+			k = nk
+		}
+
+		c.pts = dedupPolygon(c.pts, c.closed)
+
+		return c
+	}
+
+	// Open contours start at segments that no other segment leads to.
+	for k, s := range segments {
+		if !used[k] && !ends[s.from] {
+			contours = append(contours, follow(k))
+		}
+	}
+
+	for k := range segments {
+		if !used[k] {
+			contours = append(contours, follow(k))
+		}
+	}
+
+	return contours
+}
@@ -0,0 +1,116 @@
+package gfx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMarchingSquaresContours(t *testing.T) {
+	c := V(10, 10)
+
+	circle := func(u Vec) float64 { return 6 - u.To(c).Len() }
+
+	ms := NewMarchingSquares(circle, R(0, 0, 20, 20), 40, 40)
+
+	pl := ms.Contours(0)
+
+	if got, want := len(pl), 1; got != want {
+		t.Fatalf("len(pl) = %d, want %d", got, want)
+	}
+
+	for _, u := range pl[0] {
+		if d := math.Abs(u.To(c).Len() - 6); d > 0.05 {
+			t.Fatalf("distance from circle = %v", d)
+		}
+	}
+
+	if got, want := polygonArea(pl[0]), math.Pi*36; math.Abs(got-want) > 1 {
+		t.Fatalf("polygonArea(pl[0]) = %v, want %v", got, want)
+	}
+
+	if !EvenOddFillRule.Inside(pl.Winding(c)) || EvenOddFillRule.Inside(pl.Winding(V(1, 1))) {
+		t.Fatalf("unexpected pl.Contains")
+	}
+
+	band := ms.IsoBand(-2, 2)
+
+	if got, want := len(band), 2; got != want {
+		t.Fatalf("len(band) = %d, want %d", got, want)
+	}
+
+	if got, want := polygonArea(band[0])-polygonArea(band[1]), math.Pi*(64-16); math.Abs(got-want) > 1 {
+		t.Fatalf("band area = %v, want %v", got, want)
+	}
+
+	for _, tc := range []struct {
+		u    Vec
+		want bool
+	}{
+		{c, false},
+		{c.AddXY(6, 0), true},
+		{c.AddXY(0, -9), false},
+	} {
+		if got := EvenOddFillRule.Inside(band.Winding(tc.u)); got != tc.want {
+			t.Fatalf("band inside %v = %v, want %v", tc.u, got, tc.want)
+		}
+	}
+}
+
+func TestMarchingSquaresIsoLines(t *testing.T) {
+	ms := NewMarchingSquares(func(u Vec) float64 { return u.X }, R(0, 0, 10, 10), 4, 4)
+
+	pl := ms.IsoLines(3.5)
+
+	if got, want := len(pl), 1; got != want {
+		t.Fatalf("len(pl) = %d, want %d", got, want)
+	}
+
+	if got, want := len(pl[0]), 5; got != want {
+		t.Fatalf("len(pl[0]) = %d, want %d", got, want)
+	}
+
+	for _, u := range pl[0] {
+		if u.X != 3.5 {
+			t.Fatalf("u.X = %v, want 3.5", u.X)
+		}
+	}
+}
+
+func TestMarchingSquaresSaddle(t *testing.T) {
+	// Corners: 1 0
+	//          0 1
+	saddle := func(u Vec) float64 {
+		return u.X*u.Y + (1-u.X)*(1-u.Y)
+	}
+
+	ms := NewMarchingSquares(saddle, R(0, 0, 1, 1), 1, 1)
+
+	if got, want := len(ms.Contours(0.4)), 1; got != want {
+		t.Fatalf("len(ms.Contours(0.4)) = %d, want %d", got, want)
+	}
+
+	if got, want := len(ms.Contours(0.6)), 2; got != want {
+		t.Fatalf("len(ms.Contours(0.6)) = %d, want %d", got, want)
+	}
+
+	if got, want := len(ms.IsoLines(0.6)), 2; got != want {
+		t.Fatalf("len(ms.IsoLines(0.6)) = %d, want %d", got, want)
+	}
+}
+
+func TestNewImageMarchingSquares(t *testing.T) {
+	m := NewNRGBA(IR(0, 0, 8, 8))
+
+	DrawColor(m, m.Bounds(), ColorBlack)
+	DrawColor(m, IR(2, 2, 6, 6), ColorWhite)
+
+	pl := NewImageMarchingSquares(m).Contours(0.5)
+
+	if got, want := len(pl), 1; got != want {
+		t.Fatalf("len(pl) = %d, want %d", got, want)
+	}
+
+	if got, want := pl.Rect(), R(2, 2, 6, 6); got != want {
+		t.Fatalf("pl.Rect() = %v, want %v", got, want)
+	}
+}
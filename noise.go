@@ -0,0 +1,106 @@
+package gfx
+
+import "math"
+
+// Noise2D is a source of 2D noise.
+type Noise2D interface {
+	Noise2D(x, y float64) float64
+}
+
+// Noise3D is a source of 3D noise.
+type Noise3D interface {
+	Noise3D(x, y, z float64) float64
+}
+
+// Noise4D is a source of 4D noise.
+type Noise4D interface {
+	Noise4D(x, y, z, w float64) float64
+}
+
+// Noise2DFunc is a func that implements Noise2D.
+type Noise2DFunc func(x, y float64) float64
+
+// Noise2D calls fn(x, y)
+func (fn Noise2DFunc) Noise2D(x, y float64) float64 {
+	return fn(x, y)
+}
+
+// Noise3DFunc is a func that implements Noise3D.
+type Noise3DFunc func(x, y, z float64) float64
+
+// Noise3D calls fn(x, y, z)
+func (fn Noise3DFunc) Noise3D(x, y, z float64) float64 {
+	return fn(x, y, z)
+}
+
+// Noise4DFunc is a func that implements Noise4D.
+type Noise4DFunc func(x, y, z, w float64) float64
+
+// Noise4D calls fn(x, y, z, w)
+func (fn Noise4DFunc) Noise4D(x, y, z, w float64) float64 {
+	return fn(x, y, z, w)
+}
+
+var (
+	_ Noise2D = (*SimplexNoise)(nil)
+	_ Noise3D = (*SimplexNoise)(nil)
+	_ Noise4D = (*SimplexNoise)(nil)
+	_ Noise2D = (*PerlinNoise)(nil)
+	_ Noise3D = (*PerlinNoise)(nil)
+	_ Noise4D = (*PerlinNoise)(nil)
+	_ Noise2D = (*ValueNoise)(nil)
+	_ Noise3D = (*ValueNoise)(nil)
+	_ Noise4D = (*ValueNoise)(nil)
+	_ Noise2D = (*WorleyNoise)(nil)
+	_ Noise3D = (*WorleyNoise)(nil)
+)
+
+// noiseLattice interpolates the values returned by corner for each of the
+// corners of the lattice cell containing the first n (up to 4) coordinates.
+//
+// The corner func is called with the hash of the corner (using the
+// permutation table perm) and the offset of the point from the corner.
+func noiseLattice(perm []uint8, n int, coords [4]float64, corner func(h int, d [4]float64) float64) float64 {
+	var (
+		i [4]int
+		f [4]float64
+		v [16]float64
+	)
+
+	for k := 0; k < n; k++ {
+		fl := math.Floor(coords[k])
+
+		i[k] = int(fl) & 255
+		f[k] = coords[k] - fl
+	}
+
+	for c := 0; c < 1<<uint(n); c++ {
+		var d [4]float64
+
+		h := 0
+
+		for k := 0; k < n; k++ {
+			b := c >> uint(k) & 1
+
+			h = int(perm[h+i[k]+b])
+			d[k] = f[k] - float64(b)
+		}
+
+		v[c] = corner(h, d)
+	}
+
+	for k := 0; k < n; k++ {
+		u := noiseFade(f[k])
+
+		for c := 0; c < 1<<uint(n-k-1); c++ {
+			v[c] = Lerp(v[2*c], v[2*c+1], u)
+		}
+	}
+
+	return v[0]
+}
+
+// noiseFade is the quintic fade curve 6t⁵-15t⁴+10t³ of improved Perlin noise.
+func noiseFade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
@@ -0,0 +1,275 @@
+package gfx
+
+import (
+	"image/color"
+	"math"
+)
+
+// OKLab represents a color in the OKLab color space by Björn Ottosson.
+// https://bottosson.github.io/posts/oklab/
+//   - L     [0,1]
+//   - A     [-0.4,0.4] (approximately)
+//   - B     [-0.4,0.4] (approximately)
+//   - Alpha [0,1]
+type OKLab struct {
+	L     float64
+	A     float64
+	B     float64
+	Alpha float64
+}
+
+// OKLCH represents a color in the cylindrical form of OKLab.
+//   - L     [0,1]
+//   - C     [0,0.4] (approximately)
+//   - H     [0,360]
+//   - Alpha [0,1]
+type OKLCH struct {
+	L     float64
+	C     float64
+	H     float64
+	Alpha float64
+}
+
+// Color models for the OKLab and OKLCH color spaces.
+var (
+	OKLabModel = color.ModelFunc(okLabModel)
+	OKLCHModel = color.ModelFunc(okLCHModel)
+)
+
+func okLabModel(c color.Color) color.Color {
+	if c, ok := c.(OKLab); ok {
+		return c
+	}
+
+	r, g, b, a := linearNRGBA(c)
+
+	lab := linearRGBToOKLab(r, g, b)
+	lab.Alpha = a
+
+	return lab
+}
+
+func okLCHModel(c color.Color) color.Color {
+	if c, ok := c.(OKLCH); ok {
+		return c
+	}
+
+	return okLabModel(c).(OKLab).OKLCH()
+}
+
+// OKLab converts from XYZ (D65/2°) to an opaque OKLab color.
+func (xyz XYZ) OKLab() OKLab {
+	lab := linearRGBToOKLab(xyz.linearRGB())
+	lab.Alpha = 1
+
+	return lab
+}
+
+// OKLCH converts from XYZ (D65/2°) to an opaque OKLCH color.
+func (xyz XYZ) OKLCH() OKLCH {
+	return xyz.OKLab().OKLCH()
+}
+
+// RGBA implements the color.Color interface. Colors outside of
+// the sRGB gamut are mapped into the gamut using Gamut.
+func (c OKLab) RGBA() (r, g, b, a uint32) {
+	lr, lg, lb := c.linearRGB()
+
+	return colorFromLinearRGB(lr, lg, lb, c.Alpha).RGBA()
+}
+
+// XYZ converts from OKLab to XYZ (D65/2°).
+func (c OKLab) XYZ() XYZ {
+	return linearRGBToXYZ(c.linearRGB())
+}
+
+// OKLCH converts from OKLab to OKLCH.
+func (c OKLab) OKLCH() OKLCH {
+	h := math.Atan2(c.B, c.A) * 180 / math.Pi
+
+	if h < 0 {
+		h += 360
+	}
+
+	return OKLCH{
+		L:     c.L,
+		C:     math.Hypot(c.A, c.B),
+		H:     h,
+		Alpha: c.Alpha,
+	}
+}
+
+// DeltaE returns the Euclidean distance between the two OKLab colors.
+func (c OKLab) DeltaE(o OKLab) float64 {
+	return math.Sqrt((c.L-o.L)*(c.L-o.L) + (c.A-o.A)*(c.A-o.A) + (c.B-o.B)*(c.B-o.B))
+}
+
+// InGamut reports whether the color is within the sRGB gamut.
+func (c OKLab) InGamut() bool {
+	return linearRGBInGamut(c.linearRGB())
+}
+
+// Gamut maps the color into the sRGB gamut, by reducing the chroma while
+// keeping the lightness and hue, as described in CSS Color Module Level 4.
+// https://www.w3.org/TR/css-color-4/#gamut-mapping
+func (c OKLab) Gamut() OKLab {
+	switch {
+	case c.L >= 1:
+		return OKLab{L: 1, Alpha: c.Alpha}
+	case c.L <= 0:
+		return OKLab{Alpha: c.Alpha}
+	case c.InGamut():
+		return c
+	}
+
+	const (
+		jnd     = 0.02
+		epsilon = 0.0001
+	)
+
+	clip := func(c OKLab) OKLab {
+		r, g, b := c.linearRGB()
+
+		lab := linearRGBToOKLab(Clamp(r, 0, 1), Clamp(g, 0, 1), Clamp(b, 0, 1))
+		lab.Alpha = c.Alpha
+
+		return lab
+	}
+
+	if clipped := clip(c); clipped.DeltaE(c) < jnd {
+		return clipped
+	}
+
+	lch := c.OKLCH()
+
+	lo, hi := 0.0, lch.C
+	inGamut := true
+
+	for hi-lo > epsilon {
+		lch.C = (lo + hi) / 2
+
+		current := lch.OKLab()
+
+		if inGamut && current.InGamut() {
+			lo = lch.C
+
+			continue
+		}
+
+		clipped := clip(current)
+
+		if e := clipped.DeltaE(current); e < jnd {
+			if jnd-e < epsilon {
+				return clipped
+			}
+
+			inGamut = false
+			lo = lch.C
+		} else {
+			hi = lch.C
+		}
+	}
+
+	return clip(lch.OKLab())
+}
+
+// linearRGB converts from OKLab to linear R, G and B.
+func (c OKLab) linearRGB() (r, g, b float64) {
+	l := c.L + 0.3963377774*c.A + 0.2158037573*c.B
+	m := c.L - 0.1055613458*c.A - 0.0638541728*c.B
+	s := c.L - 0.0894841775*c.A - 1.2914855480*c.B
+
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	return 4.0767416621*l - 3.3077115913*m + 0.2309699292*s,
+		-1.2684380046*l + 2.6097574011*m - 0.3413193965*s,
+		-0.0041960863*l - 0.7034186147*m + 1.7076147010*s
+}
+
+// RGBA implements the color.Color interface. Colors outside of
+// the sRGB gamut are mapped into the gamut using Gamut.
+func (c OKLCH) RGBA() (r, g, b, a uint32) {
+	return c.OKLab().RGBA()
+}
+
+// XYZ converts from OKLCH to XYZ (D65/2°).
+func (c OKLCH) XYZ() XYZ {
+	return c.OKLab().XYZ()
+}
+
+// OKLab converts from OKLCH to OKLab.
+func (c OKLCH) OKLab() OKLab {
+	h := c.H * math.Pi / 180
+
+	return OKLab{
+		L:     c.L,
+		A:     c.C * math.Cos(h),
+		B:     c.C * math.Sin(h),
+		Alpha: c.Alpha,
+	}
+}
+
+// InGamut reports whether the color is within the sRGB gamut.
+func (c OKLCH) InGamut() bool {
+	return c.OKLab().InGamut()
+}
+
+// Gamut maps the color into the sRGB gamut, by reducing the chroma
+// while keeping the lightness and hue.
+func (c OKLCH) Gamut() OKLCH {
+	return c.OKLab().Gamut().OKLCH()
+}
+
+// linearRGBToOKLab converts linear R, G and B into OKLab.
+func linearRGBToOKLab(r, g, b float64) OKLab {
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	return OKLab{
+		L: 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		A: 1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		B: 0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+// linearNRGBA returns the non-premultiplied linear R, G and B, and the alpha of c.
+func linearNRGBA(c color.Color) (r, g, b, a float64) {
+	cr, cg, cb, ca := c.RGBA()
+
+	if ca == 0 {
+		return 0, 0, 0, 0
+	}
+
+	a = float64(ca)
+
+	return sRGBToLinear(float64(cr) / a),
+		sRGBToLinear(float64(cg) / a),
+		sRGBToLinear(float64(cb) / a),
+		a / 0xFFFF
+}
+
+// linearRGBInGamut reports whether linear R, G and B are within the sRGB gamut.
+func linearRGBInGamut(r, g, b float64) bool {
+	const epsilon = 1e-4
+
+	return r >= -epsilon && r <= 1+epsilon &&
+		g >= -epsilon && g <= 1+epsilon &&
+		b >= -epsilon && b <= 1+epsilon
+}
+
+// colorFromLinearRGB converts linear R, G and B, and the alpha into a
+// premultiplied color.RGBA64. Colors outside of the sRGB gamut are mapped into the gamut.
+func colorFromLinearRGB(r, g, b, a float64) color.RGBA64 {
+	if !linearRGBInGamut(r, g, b) {
+		r, g, b = linearRGBToOKLab(r, g, b).Gamut().linearRGB()
+	}
+
+	a = Clamp(a, 0, 1)
+
+	c := func(v float64) uint16 {
+		return uint16(math.Round(linearToSRGB(Clamp(v, 0, 1)) * a * 0xFFFF))
+	}
+
+	return color.RGBA64{c(r), c(g), c(b), uint16(math.Round(a * 0xFFFF))}
+}
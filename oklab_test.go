@@ -0,0 +1,124 @@
+package gfx
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+// testColors is a set of colors used to test round trips between color spaces.
+var testColors = []color.NRGBA{
+	{0, 0, 0, 255}, {255, 255, 255, 255}, {255, 0, 0, 255}, {0, 255, 0, 255},
+	{0, 0, 255, 255}, {12, 34, 56, 255}, {200, 100, 50, 128}, {1, 2, 3, 4},
+	{128, 128, 128, 255}, {250, 240, 10, 255}, {90, 0, 200, 17}, {0, 0, 0, 0},
+}
+
+func testRoundTrip(t *testing.T, m color.Model) {
+	t.Helper()
+
+	for _, c := range testColors {
+		want := color.RGBA64Model.Convert(c)
+
+		if got := color.RGBA64Model.Convert(m.Convert(c)); got != want {
+			t.Fatalf("round trip of %v = %v, want %v", c, got, want)
+		}
+	}
+}
+
+func TestOKLab(t *testing.T) {
+	lab := OKLabModel.Convert(ColorNRGBA(255, 0, 0, 255)).(OKLab)
+
+	for _, tc := range []struct {
+		got, want float64
+	}{
+		{lab.L, 0.627955}, {lab.A, 0.224863}, {lab.B, 0.125846}, {lab.Alpha, 1},
+	} {
+		if math.Abs(tc.got-tc.want) > 1e-5 {
+			t.Fatalf("OKLab = %+v", lab)
+		}
+	}
+
+	lch := lab.OKLCH()
+
+	if math.Abs(lch.C-0.257683) > 1e-5 || math.Abs(lch.H-29.2339) > 1e-3 {
+		t.Fatalf("OKLCH = %+v", lch)
+	}
+
+	if got := lch.OKLab(); got.DeltaE(lab) > 1e-12 {
+		t.Fatalf("lch.OKLab() = %+v, want %+v", got, lab)
+	}
+
+	if got, want := ColorToXYZ(ColorWhite).OKLab(), (OKLab{L: 1, Alpha: 1}); got.DeltaE(want) > 1e-3 {
+		t.Fatalf("white = %+v, want %+v", got, want)
+	}
+
+	if got := lab.XYZ(); math.Abs(got.X-41.24) > 1e-6 || math.Abs(got.Y-21.26) > 1e-6 {
+		t.Fatalf("lab.XYZ() = %+v", got)
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		testRoundTrip(t, OKLabModel)
+		testRoundTrip(t, OKLCHModel)
+	})
+}
+
+func TestOKLabGamut(t *testing.T) {
+	for _, c := range []OKLCH{
+		{0.7, 0.4, 150, 1},
+		{0.5, 0.3, 270, 0.5},
+		{0.9, 0.2, 20, 1},
+		{0.3, 0.5, 90, 1},
+	} {
+		if c.InGamut() {
+			t.Fatalf("%+v should be out of gamut", c)
+		}
+
+		g := c.Gamut()
+
+		if !g.InGamut() {
+			t.Fatalf("%+v.Gamut() = %+v is out of gamut", c, g)
+		}
+
+		if math.Abs(g.L-c.L) > 0.03 || math.Abs(g.H-c.H) > 10 || g.C >= c.C || g.Alpha != c.Alpha {
+			t.Fatalf("%+v.Gamut() = %+v", c, g)
+		}
+
+		if got, want := color.NRGBA64Model.Convert(c), color.NRGBA64Model.Convert(g); got != want {
+			t.Fatalf("color = %v, want %v", got, want)
+		}
+	}
+
+	for _, tc := range []struct {
+		c    OKLab
+		want OKLab
+	}{
+		{OKLab{L: 1.2, A: 0.1, Alpha: 1}, OKLab{L: 1, Alpha: 1}},
+		{OKLab{L: -0.1, B: 0.1, Alpha: 1}, OKLab{Alpha: 1}},
+		{OKLab{L: 0.5, A: 0.01, Alpha: 1}, OKLab{L: 0.5, A: 0.01, Alpha: 1}},
+	} {
+		if got := tc.c.Gamut(); got != tc.want {
+			t.Fatalf("%+v.Gamut() = %+v, want %+v", tc.c, got, tc.want)
+		}
+	}
+
+	t.Run("Clip", func(t *testing.T) {
+		// Slightly outside of the gamut, so clipping is within the JND.
+		lch := OKLabModel.Convert(ColorNRGBA(255, 160, 80, 255)).(OKLab).OKLCH()
+		lch.C *= 1.02
+
+		c := lch.OKLab()
+
+		if c.InGamut() {
+			t.Fatalf("%+v should be out of gamut", c)
+		}
+
+		r, g, b := c.linearRGB()
+
+		want := linearRGBToOKLab(Clamp(r, 0, 1), Clamp(g, 0, 1), Clamp(b, 0, 1))
+		want.Alpha = c.Alpha
+
+		if got := c.Gamut(); got != want {
+			t.Fatalf("%+v.Gamut() = %+v, want %+v", c, got, want)
+		}
+	})
+}
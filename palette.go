@@ -47,8 +47,12 @@ func (p Palette) Random() color.NRGBA {
 func (p Palette) Tile(src image.Image) *Paletted {
 	dst := NewPalettedImage(src.Bounds(), p)
 
+	dst.SetTree(p.Tree(RGBColorMetric))
+
 	draw.Draw(dst, dst.Bounds(), src, image.ZP, draw.Src)
 
+	dst.SetTree(nil)
+
 	return dst
 }
 
@@ -63,6 +67,9 @@ func (p Palette) Convert(c color.Color) color.Color {
 
 // Index returns the index of the palette color closest to c in Euclidean
 // R,G,B,A space.
+//
+// Use IndexMetric for other color metrics, and a PaletteTree
+// when looking up a large number of colors.
 func (p Palette) Index(c color.Color) int {
 	cr, cg, cb, ca := c.RGBA()
 	ret, bestSum := 0, uint32(1<<32-1)
@@ -83,6 +90,36 @@ func (p Palette) Index(c color.Color) int {
 	return ret
 }
 
+// IndexMetric returns the index of the palette color closest to c using the
+// metric. It is Index with a pluggable metric, where RGBColorMetric gives the
+// same result as Index.
+//
+// Each call converts all of the palette colors for the metric, so use the
+// PaletteTree returned by p.Tree(m) when looking up more than a few colors.
+func (p Palette) IndexMetric(c color.Color, m ColorMetric) int {
+	if m == RGBColorMetric {
+		return p.Index(c)
+	}
+
+	points := make([][4]float64, len(p))
+
+	for i, v := range p {
+		points[i] = m.point(v)
+	}
+
+	return m.index(m.point(c), points)
+}
+
+// ConvertMetric returns the palette color closest to c using the metric.
+// See IndexMetric.
+func (p Palette) ConvertMetric(c color.Color, m ColorMetric) color.Color {
+	if len(p) == 0 {
+		return color.RGBA{}
+	}
+
+	return p[p.IndexMetric(c, m)]
+}
+
 // AsColorPalette converts the Palette to a color.Palette.
 func (p Palette) AsColorPalette() color.Palette {
 	var cp = make(color.Palette, len(p))
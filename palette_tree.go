@@ -0,0 +1,311 @@
+package gfx
+
+import (
+	"image/color"
+	"math"
+	"sort"
+)
+
+// ColorMetric is the distance metric used when looking up the closest
+// palette color.
+type ColorMetric int
+
+const (
+	// RGBColorMetric is the Euclidean distance in R,G,B,A space,
+	// identical to Palette.Index.
+	RGBColorMetric ColorMetric = iota
+
+	// WeightedRGBColorMetric is the Euclidean distance in R,G,B,A space,
+	// with the components weighted 2, 4, 3 and 3.
+	WeightedRGBColorMetric
+
+	// CIELabColorMetric is CIELab.DeltaE, plus the difference in alpha.
+	CIELabColorMetric
+
+	// CIE94ColorMetric is CIELab.DeltaE94 with the graphic arts weights,
+	// plus the difference in alpha.
+	CIE94ColorMetric
+
+	// CIEDE2000ColorMetric is CIELab.DeltaE2000, plus the difference in alpha.
+	CIEDE2000ColorMetric
+
+	// CMCColorMetric is CIELab.DeltaCMC with l:c of 2:1, plus the difference in alpha.
+	CMCColorMetric
+)
+
+// point returns the coordinates of the color in the space of the metric.
+func (m ColorMetric) point(c color.Color) [4]float64 {
+	r, g, b, a := c.RGBA()
+
+	switch m {
+	case WeightedRGBColorMetric:
+		return [4]float64{
+			float64(r) * math.Sqrt2,
+			float64(g) * 2,
+			float64(b) * math.Sqrt(3),
+			float64(a) * math.Sqrt(3),
+		}
+	case CIELabColorMetric, CIE94ColorMetric, CIEDE2000ColorMetric, CMCColorMetric:
+		lab := ColorToXYZ(c).CIELab(XYZReference2.D65)
+
+		return [4]float64{lab.L, lab.A, lab.B, float64(a) / 0xFFFF * 100}
+	default:
+		return [4]float64{float64(r), float64(g), float64(b), float64(a)}
+	}
+}
+
+// distance returns the (squared) distance between the points,
+// where p is the reference color for the asymmetric metrics.
+func (m ColorMetric) distance(p, q [4]float64) float64 {
+	switch m {
+	case RGBColorMetric:
+		var sum uint32
+
+		for i := range p {
+			sum += sqDiff(uint32(p[i]), uint32(q[i]))
+		}
+
+		return float64(sum)
+	case CIELabColorMetric:
+		d := CIELab{p[0], p[1], p[2]}.DeltaE(CIELab{q[0], q[1], q[2]})
+
+		return d*d + (p[3]-q[3])*(p[3]-q[3])
+	case CIE94ColorMetric:
+		d := CIELab{p[0], p[1], p[2]}.DeltaE94(CIELab{q[0], q[1], q[2]}, CIE94WeightsGraphicArts)
+
+		return d*d + (p[3]-q[3])*(p[3]-q[3])
+	case CIEDE2000ColorMetric:
+		d := CIELab{p[0], p[1], p[2]}.DeltaE2000(CIELab{q[0], q[1], q[2]})
+
+		return d*d + (p[3]-q[3])*(p[3]-q[3])
+	case CMCColorMetric:
+		d := CIELab{p[0], p[1], p[2]}.DeltaCMC(CIELab{q[0], q[1], q[2]}, 2, 1)
+
+		return d*d + (p[3]-q[3])*(p[3]-q[3])
+	default:
+		var sum float64
+
+		for i := range p {
+			sum += (p[i] - q[i]) * (p[i] - q[i])
+		}
+
+		return sum
+	}
+}
+
+// euclidean reports whether the metric is the Euclidean distance
+// between points, which is required to search the k-d tree.
+func (m ColorMetric) euclidean() bool {
+	switch m {
+	case CIE94ColorMetric, CIEDE2000ColorMetric, CMCColorMetric:
+		return false
+	default:
+		return true
+	}
+}
+
+// bound returns the smallest possible distance to any point that
+// differs by d along a single axis.
+func (m ColorMetric) bound(d float64) float64 {
+	if m == RGBColorMetric {
+		return float64(sqDiff(uint32(math.Abs(d)), 0))
+	}
+
+	return d * d
+}
+
+// PaletteTree is a precomputed k-d tree used to quickly find
+// the closest color in a Palette using a ColorMetric.
+//
+// The CIE94, CIEDE2000 and CMC metrics are not Euclidean distances, so
+// those are looked up by comparing against every (precomputed) color.
+//
+// The tree needs to be recreated if the palette is modified.
+type PaletteTree struct {
+	palette Palette
+	metric  ColorMetric
+	points  [][4]float64
+	nodes   []paletteTreeNode
+	root    int
+}
+
+type paletteTreeNode struct {
+	point       [4]float64
+	index       int
+	axis        int
+	left, right int
+}
+
+// Tree returns a new PaletteTree for the palette using the given metric.
+func (p Palette) Tree(m ColorMetric) *PaletteTree {
+	return NewPaletteTree(p, m)
+}
+
+// NewPaletteTree creates a new PaletteTree for the palette using the given metric.
+func NewPaletteTree(p Palette, m ColorMetric) *PaletteTree {
+	pt := &PaletteTree{
+		palette: p,
+		metric:  m,
+		points:  make([][4]float64, len(p)),
+		nodes:   make([]paletteTreeNode, 0, len(p)),
+	}
+
+	indexes := make([]int, len(p))
+
+	for i, c := range p {
+		pt.points[i] = m.point(c)
+		indexes[i] = i
+	}
+
+	pt.root = -1
+
+	if m.euclidean() {
+		pt.root = pt.build(indexes)
+	}
+
+	return pt
+}
+
+// build creates the nodes of the tree, splitting on the axis with the largest spread.
+func (pt *PaletteTree) build(indexes []int) int {
+	if len(indexes) == 0 {
+		return -1
+	}
+
+	axis, spread := 0, -1.0
+
+	for a := 0; a < 4; a++ {
+		lo, hi := math.Inf(1), math.Inf(-1)
+
+		for _, i := range indexes {
+			lo, hi = math.Min(lo, pt.points[i][a]), math.Max(hi, pt.points[i][a])
+		}
+
+		if hi-lo > spread {
+			axis, spread = a, hi-lo
+		}
+	}
+
+	sort.Slice(indexes, func(i, j int) bool {
+		return pt.points[indexes[i]][axis] < pt.points[indexes[j]][axis]
+	})
+
+	m := len(indexes) / 2
+
+	n := len(pt.nodes)
+
+	pt.nodes = append(pt.nodes, paletteTreeNode{
+		point: pt.points[indexes[m]],
+		index: indexes[m],
+		axis:  axis,
+	})
+
+	left := pt.build(append([]int{}, indexes[:m]...))
+	right := pt.build(append([]int{}, indexes[m+1:]...))
+
+	pt.nodes[n].left, pt.nodes[n].right = left, right
+
+	return n
+}
+
+// Palette returns the palette of the tree.
+func (pt *PaletteTree) Palette() Palette {
+	return pt.palette
+}
+
+// Metric returns the color metric of the tree.
+func (pt *PaletteTree) Metric() ColorMetric {
+	return pt.metric
+}
+
+// usable reports whether the tree was created for the palette p.
+func (pt *PaletteTree) usable(p Palette) bool {
+	return len(pt.palette) == len(p) && (len(p) == 0 || &pt.palette[0] == &p[0])
+}
+
+// Index returns the index of the palette color closest to c.
+//
+// Ties are resolved in favor of the lowest index, just like Palette.Index.
+func (pt *PaletteTree) Index(c color.Color) int {
+	q := pt.metric.point(c)
+
+	if !pt.metric.euclidean() {
+		return pt.metric.index(q, pt.points)
+	}
+
+	if pt.root < 0 {
+		return 0
+	}
+
+	best, bestDist := -1, math.Inf(1)
+
+	// Stack of nodes to visit, along with the smallest possible distance.
+	type visit struct {
+		n     int
+		bound float64
+	}
+
+	var (
+		stack = make([]visit, 1, 64)
+		v     visit
+	)
+
+	stack[0] = visit{pt.root, 0}
+
+	for len(stack) > 0 {
+		v, stack = stack[len(stack)-1], stack[:len(stack)-1]
+
+		if v.bound > bestDist {
+			continue
+		}
+
+		node := &pt.nodes[v.n]
+
+		if d := pt.metric.distance(q, node.point); d < bestDist || (d == bestDist && node.index < best) {
+			best, bestDist = node.index, d
+		}
+
+		diff := q[node.axis] - node.point[node.axis]
+
+		near, far := node.left, node.right
+
+		if diff >= 0 {
+			near, far = far, near
+		}
+
+		if far >= 0 {
+			if bound := pt.metric.bound(diff); bound <= bestDist {
+				stack = append(stack, visit{far, bound})
+			}
+		}
+
+		if near >= 0 {
+			stack = append(stack, visit{near, v.bound})
+		}
+	}
+
+	return best
+}
+
+// Convert returns the palette color closest to c.
+func (pt *PaletteTree) Convert(c color.Color) color.Color {
+	if len(pt.palette) == 0 {
+		return color.RGBA{}
+	}
+
+	return pt.palette[pt.Index(c)]
+}
+
+// index returns the index of the point closest to q,
+// comparing against every point.
+func (m ColorMetric) index(q [4]float64, points [][4]float64) int {
+	best, bestDist := 0, math.Inf(1)
+
+	for i, p := range points {
+		if d := m.distance(q, p); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+
+	return best
+}
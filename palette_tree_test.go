@@ -0,0 +1,99 @@
+package gfx
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPaletteTreeIndex(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	random := func() color.NRGBA {
+		return ColorNRGBA(uint8(r.Intn(256)), uint8(r.Intn(256)), uint8(r.Intn(256)), uint8(r.Intn(256)))
+	}
+
+	p := Palette{}
+
+	for i := 0; i < 64; i++ {
+		p = append(p, random())
+	}
+
+	for _, p := range []Palette{PaletteSplendor128, PaletteEN4, p, append(Palette{ColorBlack}, ColorBlack, ColorWhite)} {
+		pt := p.Tree(RGBColorMetric)
+
+		for i := 0; i < 2000; i++ {
+			c := random()
+
+			if i%2 == 0 {
+				c.A = 255
+			}
+
+			if got, want := pt.Index(c), p.Index(c); got != want {
+				t.Fatalf("pt.Index(%v) = %d, want %d", c, got, want)
+			}
+		}
+	}
+}
+
+func TestPaletteTreeMetrics(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+
+	p := PaletteSplendor128
+
+	for _, m := range []ColorMetric{
+		WeightedRGBColorMetric, CIELabColorMetric, CIE94ColorMetric, CIEDE2000ColorMetric, CMCColorMetric,
+	} {
+		pt := p.Tree(m)
+
+		for i := 0; i < 500; i++ {
+			c := ColorNRGBA(uint8(r.Intn(256)), uint8(r.Intn(256)), uint8(r.Intn(256)), 255)
+			q := m.point(c)
+
+			want, bestDist := 0, math.Inf(1)
+
+			for j, pc := range p {
+				if d := m.distance(q, m.point(pc)); d < bestDist {
+					want, bestDist = j, d
+				}
+			}
+
+			if got := pt.Index(c); got != want {
+				t.Fatalf("pt.Index(%v) = %d, want %d", c, got, want)
+			}
+
+			if got := p.IndexMetric(c, m); got != want {
+				t.Fatalf("p.IndexMetric(%v, %d) = %d, want %d", c, m, got, want)
+			}
+		}
+	}
+}
+
+func TestPalettedSetTree(t *testing.T) {
+	m := NewPaletted(2, 1, PaletteEN4)
+
+	m.SetTree(PaletteEN4.Tree(CIELabColorMetric))
+	m.Set(0, 0, ColorNRGBA(30, 40, 20, 255))
+
+	if got, want := m.Index(0, 0), uint8(PaletteEN4.Tree(CIELabColorMetric).Index(ColorNRGBA(30, 40, 20, 255))); got != want {
+		t.Fatalf("m.Index(0, 0) = %d, want %d", got, want)
+	}
+}
+
+func TestPaletteIndexMetric(t *testing.T) {
+	p := Palette{ColorNRGBA(0, 0, 255, 255), ColorNRGBA(90, 60, 200, 255), ColorNRGBA(40, 40, 40, 255)}
+	c := ColorNRGBA(60, 40, 220, 255)
+
+	if got, want := p.IndexMetric(c, RGBColorMetric), p.Index(c); got != want {
+		t.Fatalf("p.IndexMetric(c, RGBColorMetric) = %d, want %d", got, want)
+	}
+
+	if got, want := p.ConvertMetric(c, CIEDE2000ColorMetric), p[p.Tree(CIEDE2000ColorMetric).Index(c)]; got != want {
+		t.Fatalf("p.ConvertMetric(c, CIEDE2000ColorMetric) = %v, want %v", got, want)
+	}
+
+	if got, want := (Palette{}).ConvertMetric(c, CMCColorMetric), (color.RGBA{}); got != want {
+		t.Fatalf("ConvertMetric on empty palette = %v, want %v", got, want)
+	}
+}
@@ -32,6 +32,8 @@ type Paletted struct {
 	Rect image.Rectangle
 	// Palette is the image's palette.
 	Palette Palette
+
+	tree *PaletteTree
 }
 
 // NewPaletted returns a new paletted image with the given width, height and palette.
@@ -59,7 +61,8 @@ func NewPalettedImage(r image.Rectangle, p Palette) *Paletted {
 	}
 }
 
-// NewResizedPalettedImage returns an image with the provided dimensions.
+// NewResizedPalettedImage returns an image with the provided dimensions,
+// using nearest neighbor scaling.
 func NewResizedPalettedImage(src PalettedImage, w, h int) *Paletted {
 	dst := NewPalettedImage(IR(0, 0, w, h), src.GfxPalette())
 
@@ -144,9 +147,21 @@ func (p *Paletted) Set(x, y int, c color.Color) {
 
 	i := p.PixOffset(x, y)
 
+	if p.tree != nil && p.tree.usable(p.Palette) {
+		p.Pix[i] = uint8(p.tree.Index(c))
+		return
+	}
+
 	p.Pix[i] = uint8(p.Palette.Index(c))
 }
 
+// SetTree sets the PaletteTree used by Set to find the closest palette color.
+//
+// The tree is ignored if it was not created for the current palette.
+func (p *Paletted) SetTree(pt *PaletteTree) {
+	p.tree = pt
+}
+
 // Index returns the color index at (x, y). (Short for ColorIndexAt)
 func (p *Paletted) Index(x, y int) uint8 {
 	return p.ColorIndexAt(x, y)
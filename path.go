@@ -0,0 +1,346 @@
+package gfx
+
+import (
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// PathTolerance is the default flattening tolerance used when filling paths.
+const PathTolerance = 0.1
+
+// Path is a vector path consisting of one or more contours built from
+// straight lines, quadratic and cubic Bézier curves, and elliptical arcs.
+//
+// The zero value is an empty path ready to use:
+//
+//   var p gfx.Path
+//
+//   p.MoveTo(gfx.V(10, 10))
+//   p.LineTo(gfx.V(90, 10))
+//   p.QuadTo(gfx.V(90, 90), gfx.V(10, 90))
+//   p.Close()
+type Path struct {
+	segs    []pathSegment
+	start   Vec
+	current Vec
+	started bool
+}
+
+type pathVerb int
+
+const (
+	pathMoveTo pathVerb = iota
+	pathLineTo
+	pathQuadTo
+	pathCubicTo
+	pathClose
+)
+
+type pathSegment struct {
+	verb pathVerb
+	pts  [3]Vec
+}
+
+// pathContour is a flattened contour of a path.
+type pathContour struct {
+	pts    Polygon
+	closed bool
+}
+
+// MoveTo starts a new contour at the given vector.
+func (p *Path) MoveTo(u Vec) {
+	p.segs = append(p.segs, pathSegment{verb: pathMoveTo, pts: [3]Vec{u}})
+	p.start, p.current, p.started = u, u, true
+}
+
+// LineTo adds a straight line from the current point to the given vector.
+func (p *Path) LineTo(u Vec) {
+	p.ensureStarted()
+
+	p.segs = append(p.segs, pathSegment{verb: pathLineTo, pts: [3]Vec{u}})
+	p.current = u
+}
+
+// QuadTo adds a quadratic Bézier curve from the current point
+// to the given vector, using the provided control point.
+func (p *Path) QuadTo(ctrl, u Vec) {
+	p.ensureStarted()
+
+	p.segs = append(p.segs, pathSegment{verb: pathQuadTo, pts: [3]Vec{ctrl, u}})
+	p.current = u
+}
+
+// CubicTo adds a cubic Bézier curve from the current point
+// to the given vector, using the two provided control points.
+func (p *Path) CubicTo(ctrl1, ctrl2, u Vec) {
+	p.ensureStarted()
+
+	p.segs = append(p.segs, pathSegment{verb: pathCubicTo, pts: [3]Vec{ctrl1, ctrl2, u}})
+	p.current = u
+}
+
+// ArcTo adds an elliptical arc from the current point to the given vector,
+// using the same parameters as the SVG arc command.
+//
+// The radius is rotated by the given angle in radians. Out of the four
+// candidate arcs, largeArc and sweep select which one to use.
+func (p *Path) ArcTo(radius Vec, rotation float64, largeArc, sweep bool, u Vec) {
+	p.ensureStarted()
+
+	from := p.current
+
+	if from == u {
+		return
+	}
+
+	rx, ry := math.Abs(radius.X), math.Abs(radius.Y)
+
+	if rx == 0 || ry == 0 {
+		p.LineTo(u)
+		return
+	}
+
+	sin, cos := math.Sincos(rotation)
+
+	// Conversion from endpoint to center parameterization as described in
+	// https://www.w3.org/TR/SVG11/implnote.html#ArcConversionEndpointToCenter
+	d := from.Sub(u).Scaled(0.5)
+	x1, y1 := cos*d.X+sin*d.Y, -sin*d.X+cos*d.Y
+
+	if l := (x1*x1)/(rx*rx) + (y1*y1)/(ry*ry); l > 1 {
+		rx, ry = rx*math.Sqrt(l), ry*math.Sqrt(l)
+	}
+
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+
+	k := math.Sqrt(math.Max(0, num/den))
+
+	if largeArc == sweep {
+		k = -k
+	}
+
+	cx, cy := k*rx*y1/ry, -k*ry*x1/rx
+
+	m := from.Lerp(u, 0.5)
+	center := V(cos*cx-sin*cy+m.X, sin*cx+cos*cy+m.Y)
+
+	theta := V(1, 0).angleTo(V((x1-cx)/rx, (y1-cy)/ry))
+	delta := V((x1-cx)/rx, (y1-cy)/ry).angleTo(V((-x1-cx)/rx, (-y1-cy)/ry))
+
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	}
+
+	if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	p.arc(center, V(rx, ry), sin, cos, theta, delta, u)
+}
+
+// arc adds an elliptical arc as a series of cubic Bézier curves,
+// each one spanning at most a quarter of a turn.
+func (p *Path) arc(center, r Vec, sin, cos, theta, delta float64, end Vec) {
+	n := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+
+	if n == 0 {
+		return
+	}
+
+	step := delta / float64(n)
+	k := 4.0 / 3 * math.Tan(step/4)
+
+	point := func(t float64) (Vec, Vec) {
+		st, ct := math.Sincos(t)
+
+		pos := V(r.X*ct, r.Y*st)
+		tan := V(-r.X*st, r.Y*ct)
+
+		return center.AddXY(cos*pos.X-sin*pos.Y, sin*pos.X+cos*pos.Y),
+			V(cos*tan.X-sin*tan.Y, sin*tan.X+cos*tan.Y)
+	}
+
+	p0, d0 := point(theta)
+
+	for i := 1; i <= n; i++ {
+		p1, d1 := point(theta + step*float64(i))
+
+		if i == n {
+			p1 = end
+		}
+
+		p.CubicTo(p0.Add(d0.Scaled(k)), p1.Sub(d1.Scaled(k)), p1)
+
+		p0, d0 = p1, d1
+	}
+}
+
+// Close closes the current contour with a straight line back to its start.
+func (p *Path) Close() {
+	if !p.started {
+		return
+	}
+
+	p.segs = append(p.segs, pathSegment{verb: pathClose})
+	p.current = p.start
+	p.started = false
+}
+
+// Current returns the current point of the path.
+func (p *Path) Current() Vec {
+	return p.current
+}
+
+// Project creates a new Path with all points projected through the given Matrix.
+func (p *Path) Project(m Matrix) *Path {
+	pp := &Path{
+		segs:    make([]pathSegment, len(p.segs)),
+		start:   m.Project(p.start),
+		current: m.Project(p.current),
+		started: p.started,
+	}
+
+	for i, s := range p.segs {
+		for j := range s.pts {
+			s.pts[j] = m.Project(s.pts[j])
+		}
+
+		pp.segs[i] = s
+	}
+
+	return pp
+}
+
+// Rect returns the Rect of the path flattened using PathTolerance.
+func (p *Path) Rect() Rect {
+	return p.Flatten(PathTolerance).Rect()
+}
+
+// Flatten returns each contour of the path as a Polygon of straight line segments.
+//
+// No point on a curve deviates more than tolerance from the line segments.
+func (p *Path) Flatten(tolerance float64) Polyline {
+	var pl Polyline
+
+	for _, c := range p.contours(tolerance) {
+		pl = append(pl, c.pts)
+	}
+
+	return pl
+}
+
+// Contains returns true if the vector is inside the path according to the given FillRule.
+func (p *Path) Contains(u Vec, fr FillRule) bool {
+	return fr.Inside(p.Flatten(PathTolerance).Winding(u))
+}
+
+// EachPixel calls the provided function for each pixel inside the path.
+func (p *Path) EachPixel(fr FillRule, fn func(x, y int)) {
+	p.Flatten(PathTolerance).EachPixel(fr, fn)
+}
+
+// Fill path on the image with the given color and FillRule.
+func (p *Path) Fill(dst draw.Image, c color.Color, fr FillRule) (drawCount int) {
+	return p.Flatten(PathTolerance).Fill(dst, c, fr)
+}
+
+// FillAA fills the anti-aliased path on the image with the given color and FillRule.
+func (p *Path) FillAA(dst draw.Image, c color.Color, fr FillRule) (drawCount int) {
+	return p.Flatten(PathTolerance).FillAA(dst, c, fr)
+}
+
+// Stroke draws the anti-aliased outline of the path using the given Stroke.
+func (p *Path) Stroke(dst draw.Image, c color.Color, s Stroke) (drawCount int) {
+	return s.PathOutline(p).FillAA(dst, c, NonZeroFillRule)
+}
+
+func (p *Path) ensureStarted() {
+	if !p.started {
+		p.MoveTo(p.current)
+	}
+}
+
+func (p *Path) contours(tolerance float64) []pathContour {
+	var (
+		cs  []pathContour
+		cur Polygon
+	)
+
+	flush := func(closed bool) {
+		if len(cur) > 1 {
+			cs = append(cs, pathContour{pts: cur, closed: closed})
+		}
+
+		cur = nil
+	}
+
+	for _, s := range p.segs {
+		switch s.verb {
+		case pathMoveTo:
+			flush(false)
+			cur = Polygon{s.pts[0]}
+		case pathLineTo:
+			cur = append(cur, s.pts[0])
+		case pathQuadTo:
+			cur = flattenQuad(cur, cur[len(cur)-1], s.pts[0], s.pts[1], tolerance)
+		case pathCubicTo:
+			cur = flattenCubic(cur, cur[len(cur)-1], s.pts[0], s.pts[1], s.pts[2], tolerance)
+		case pathClose:
+			if n := len(cur); n > 1 && cur[0] == cur[n-1] {
+				cur = cur[:n-1]
+			}
+
+			flush(true)
+		}
+	}
+
+	flush(false)
+
+	return cs
+}
+
+// flattenQuad appends the flattened quadratic Bézier curve to pts.
+//
+// The number of segments is chosen so that the maximum distance between
+// the curve and the chords is within the tolerance.
+func flattenQuad(pts Polygon, p0, p1, p2 Vec, tolerance float64) Polygon {
+	dd := p0.Sub(p1.Scaled(2)).Add(p2).Len()
+	n := IntMax(1, int(math.Ceil(math.Sqrt(dd/(4*tolerance)))))
+
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		mt := 1 - t
+
+		pts = append(pts, p0.Scaled(mt*mt).Add(p1.Scaled(2*mt*t)).Add(p2.Scaled(t*t)))
+	}
+
+	return pts
+}
+
+// flattenCubic appends the flattened cubic Bézier curve to pts.
+func flattenCubic(pts Polygon, p0, p1, p2, p3 Vec, tolerance float64) Polygon {
+	dd := math.Max(
+		p0.Sub(p1.Scaled(2)).Add(p2).Len(),
+		p1.Sub(p2.Scaled(2)).Add(p3).Len(),
+	)
+	n := IntMax(1, int(math.Ceil(math.Sqrt(3*dd/(4*tolerance)))))
+
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		mt := 1 - t
+
+		pts = append(pts, p0.Scaled(mt*mt*mt).
+			Add(p1.Scaled(3*mt*mt*t)).
+			Add(p2.Scaled(3*mt*t*t)).
+			Add(p3.Scaled(t*t*t)))
+	}
+
+	return pts
+}
+
+// angleTo returns the signed angle from u to v.
+func (u Vec) angleTo(v Vec) float64 {
+	return math.Atan2(u.Cross(v), u.Dot(v))
+}
@@ -0,0 +1,118 @@
+package gfx
+
+import (
+	"math"
+	"testing"
+)
+
+func newTestPathWithHole() *Path {
+	var p Path
+
+	p.MoveTo(V(0, 0))
+	p.LineTo(V(10, 0))
+	p.LineTo(V(10, 10))
+	p.LineTo(V(0, 10))
+	p.Close()
+
+	p.MoveTo(V(3, 3))
+	p.LineTo(V(7, 3))
+	p.LineTo(V(7, 7))
+	p.LineTo(V(3, 7))
+	p.Close()
+
+	return &p
+}
+
+func TestPathContains(t *testing.T) {
+	p := newTestPathWithHole()
+
+	for _, tc := range []struct {
+		u    Vec
+		fr   FillRule
+		want bool
+	}{
+		{V(1, 1), NonZeroFillRule, true},
+		{V(1, 1), EvenOddFillRule, true},
+		{V(5, 5), NonZeroFillRule, true},
+		{V(5, 5), EvenOddFillRule, false},
+		{V(11, 5), NonZeroFillRule, false},
+	} {
+		if got := p.Contains(tc.u, tc.fr); got != tc.want {
+			t.Fatalf("p.Contains(%v, %d) = %v, want %v", tc.u, tc.fr, got, tc.want)
+		}
+	}
+}
+
+func TestPathFlattenQuad(t *testing.T) {
+	var p Path
+
+	p.MoveTo(V(0, 0))
+	p.QuadTo(V(50, 100), V(100, 0))
+
+	pl := p.Flatten(0.25)
+
+	if got, want := len(pl), 1; got != want {
+		t.Fatalf("len(pl) = %d, want %d", got, want)
+	}
+
+	pg := pl[0]
+
+	if got, want := pg[len(pg)-1], V(100, 0); got != want {
+		t.Fatalf("last point = %v, want %v", got, want)
+	}
+
+	if got, want := pl.Rect().Max.Y, 50.0; math.Abs(got-want) > 0.25 {
+		t.Fatalf("pl.Rect().Max.Y = %v, want %v", got, want)
+	}
+}
+
+func TestPathArcTo(t *testing.T) {
+	var p Path
+
+	p.MoveTo(V(10, 0))
+	p.ArcTo(V(10, 10), 0, false, true, V(-10, 0))
+
+	for _, u := range p.Flatten(0.01)[0] {
+		if got, want := u.Len(), 10.0; math.Abs(got-want) > 0.01 {
+			t.Fatalf("u.Len() = %v, want %v", got, want)
+		}
+	}
+
+	if got, want := p.Rect().Max.Y, 10.0; math.Abs(got-want) > 0.01 {
+		t.Fatalf("p.Rect().Max.Y = %v, want %v", got, want)
+	}
+}
+
+func TestPathProject(t *testing.T) {
+	p := newTestPathWithHole().Project(IM.Moved(V(5, 5)))
+
+	if got, want := p.Rect(), R(5, 5, 15, 15); got != want {
+		t.Fatalf("p.Rect() = %v, want %v", got, want)
+	}
+}
+
+func TestPathFill(t *testing.T) {
+	p := newTestPathWithHole()
+
+	dst := NewImage(12, 12)
+
+	if got, want := p.Fill(dst, ColorRed, EvenOddFillRule), 84; got != want {
+		t.Fatalf("p.Fill(dst, ColorRed, EvenOddFillRule) = %d, want %d", got, want)
+	}
+
+	if got, want := p.FillAA(dst, ColorBlue, NonZeroFillRule), 100; got != want {
+		t.Fatalf("p.FillAA(dst, ColorBlue, NonZeroFillRule) = %d, want %d", got, want)
+	}
+}
+
+func TestPolygonWinding(t *testing.T) {
+	p := Polygon{{0, 0}, {4, 0}, {4, 4}, {0, 4}}
+
+	if got, want := p.Winding(V(2, 2)), 1; got != want {
+		t.Fatalf("p.Winding(V(2, 2)) = %d, want %d", got, want)
+	}
+
+	if got, want := p.Winding(V(5, 2)), 0; got != want {
+		t.Fatalf("p.Winding(V(5, 2)) = %d, want %d", got, want)
+	}
+}
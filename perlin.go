@@ -0,0 +1,36 @@
+package gfx
+
+// PerlinNoise is an implementation of improved Perlin noise for 2D, 3D and 4D,
+// returning values approximately in the range [-1, 1].
+//
+// Based on the reference implementation by Ken Perlin.
+// https://mrl.nyu.edu/~perlin/noise/
+type PerlinNoise struct {
+	perm []uint8
+}
+
+// NewPerlinNoise creates a new Perlin noise instance with the given seed
+func NewPerlinNoise(seed int64) *PerlinNoise {
+	return &PerlinNoise{noisePermutation(seed)}
+}
+
+// Noise2D performs 2D Perlin noise
+func (pn *PerlinNoise) Noise2D(x, y float64) float64 {
+	return noiseLattice(pn.perm, 2, [4]float64{x, y}, func(h int, d [4]float64) float64 {
+		return grad3[h%12].dot2(d[0], d[1])
+	})
+}
+
+// Noise3D performs 3D Perlin noise
+func (pn *PerlinNoise) Noise3D(x, y, z float64) float64 {
+	return noiseLattice(pn.perm, 3, [4]float64{x, y, z}, func(h int, d [4]float64) float64 {
+		return grad3[h%12].dot3(d[0], d[1], d[2])
+	})
+}
+
+// Noise4D performs 4D Perlin noise
+func (pn *PerlinNoise) Noise4D(x, y, z, w float64) float64 {
+	return noiseLattice(pn.perm, 4, [4]float64{x, y, z, w}, func(h int, d [4]float64) float64 {
+		return grad4[h%32].dot4(d[0], d[1], d[2], d[3])
+	})
+}
@@ -0,0 +1,63 @@
+package gfx
+
+import "testing"
+
+func TestPerlinNoise(t *testing.T) {
+	pn := NewPerlinNoise(1234)
+
+	t.Run("Lattice", func(t *testing.T) {
+		for _, got := range []float64{
+			pn.Noise2D(3, -7),
+			pn.Noise3D(1, 2, 3),
+			pn.Noise4D(-1, 5, 2, 8),
+		} {
+			if got != 0 {
+				t.Fatalf("noise at lattice point = %v, want 0", got)
+			}
+		}
+	})
+
+	t.Run("Deterministic", func(t *testing.T) {
+		if got, want := pn.Noise3D(1.2, 3.4, 5.6), NewPerlinNoise(1234).Noise3D(1.2, 3.4, 5.6); got != want {
+			t.Fatalf("pn.Noise3D = %v, want %v", got, want)
+		}
+
+		if NewPerlinNoise(4321).Noise3D(1.2, 3.4, 5.6) == pn.Noise3D(1.2, 3.4, 5.6) {
+			t.Fatalf("expected different noise for different seeds")
+		}
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		var nonZero bool
+
+		for y := -5.0; y < 5; y += 0.37 {
+			for x := -5.0; x < 5; x += 0.29 {
+				for _, v := range []float64{
+					pn.Noise2D(x, y),
+					pn.Noise3D(x, y, x*y),
+					pn.Noise4D(x, y, x-y, x+y),
+				} {
+					if v < -1.2 || v > 1.2 {
+						t.Fatalf("noise at (%v, %v) = %v", x, y, v)
+					}
+
+					if v != 0 {
+						nonZero = true
+					}
+				}
+			}
+		}
+
+		if !nonZero {
+			t.Fatalf("expected non zero noise")
+		}
+	})
+
+	t.Run("Continuous", func(t *testing.T) {
+		for x := 0.0; x < 4; x += 0.01 {
+			if d := MathAbs(pn.Noise2D(x, 0.5) - pn.Noise2D(x+0.001, 0.5)); d > 0.01 {
+				t.Fatalf("noise changed by %v at x = %v", d, x)
+			}
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// ImagePicture is a PictureColor backed by an image.Image.
+type ImagePicture struct {
+	src image.Image
+	r   Rect
+}
+
+var _ PictureColor = (*ImagePicture)(nil)
+
+// NewImagePicture creates a new ImagePicture based on the given image.
+func NewImagePicture(src image.Image) *ImagePicture {
+	return &ImagePicture{
+		src: src,
+		r:   BoundsToRect(src.Bounds()),
+	}
+}
+
+// Image returns the underlying image.
+func (ip *ImagePicture) Image() image.Image {
+	return ip.src
+}
+
+// Bounds returns the bounds of the picture.
+func (ip *ImagePicture) Bounds() Rect {
+	return ip.r
+}
+
+// Color returns the color of the pixel containing the given vector.
+//
+// Positions outside of the picture bounds are fully transparent.
+func (ip *ImagePicture) Color(at Vec) color.NRGBA {
+	x, y := int(math.Floor(at.X)), int(math.Floor(at.Y))
+
+	if !(image.Point{x, y}.In(ip.src.Bounds())) {
+		return ColorTransparent
+	}
+
+	return color.NRGBAModel.Convert(ip.src.At(x, y)).(color.NRGBA)
+}
+
+// PictureColorBilinear returns the bilinear interpolation of the
+// four pixels in pic closest to the given vector.
+func PictureColorBilinear(pic PictureColor, at Vec) color.NRGBA {
+	p := at.AddXY(-0.5, -0.5)
+
+	x0, y0 := math.Floor(p.X), math.Floor(p.Y)
+	tx, ty := p.X-x0, p.Y-y0
+
+	var r, g, b, a float64
+
+	for _, s := range []struct {
+		x, y, w float64
+	}{
+		{x0, y0, (1 - tx) * (1 - ty)},
+		{x0 + 1, y0, tx * (1 - ty)},
+		{x0, y0 + 1, (1 - tx) * ty},
+		{x0 + 1, y0 + 1, tx * ty},
+	} {
+		if s.w == 0 {
+			continue
+		}
+
+		c := pic.Color(V(s.x+0.5, s.y+0.5))
+		ca := float64(c.A) * s.w
+
+		r += float64(c.R) * ca
+		g += float64(c.G) * ca
+		b += float64(c.B) * ca
+		a += ca
+	}
+
+	if a == 0 {
+		return ColorTransparent
+	}
+
+	return color.NRGBA{
+		uint8(Clamp(math.Round(r/a), 0, 255)),
+		uint8(Clamp(math.Round(g/a), 0, 255)),
+		uint8(Clamp(math.Round(b/a), 0, 255)),
+		uint8(Clamp(math.Round(a), 0, 255)),
+	}
+}
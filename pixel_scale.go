@@ -0,0 +1,472 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// pixelArtSource provides clamped access to the pixels of a PalettedImage.
+type pixelArtSource struct {
+	src  PalettedImage
+	b    image.Rectangle
+	w, h int
+}
+
+func newPixelArtSource(src PalettedImage) pixelArtSource {
+	b := src.Bounds()
+
+	return pixelArtSource{src, b, b.Dx(), b.Dy()}
+}
+
+// index returns the color index at x, y (relative to the bounds), clamped to the edges.
+func (s pixelArtSource) index(x, y int) uint8 {
+	return s.src.ColorIndexAt(s.b.Min.X+IntClamp(x, 0, s.w-1), s.b.Min.Y+IntClamp(y, 0, s.h-1))
+}
+
+// nrgba returns the color at x, y (relative to the bounds), clamped to the edges.
+func (s pixelArtSource) nrgba(x, y int) color.NRGBA {
+	return s.src.NRGBAAt(s.b.Min.X+IntClamp(x, 0, s.w-1), s.b.Min.Y+IntClamp(y, 0, s.h-1))
+}
+
+// scalePaletted creates a paletted image scaled by n, where fn returns
+// the n×n color indexes (row by row) for the pixel at x, y.
+func scalePaletted(src PalettedImage, n int, fn func(s pixelArtSource, x, y int, out []uint8)) *Paletted {
+	s := newPixelArtSource(src)
+
+	dst := NewPalettedImage(IR(0, 0, s.w*n, s.h*n), src.GfxPalette())
+
+	out := make([]uint8, n*n)
+
+	for y := 0; y < s.h; y++ {
+		for x := 0; x < s.w; x++ {
+			fn(s, x, y, out)
+
+			for i, ci := range out {
+				dst.SetColorIndex(x*n+i%n, y*n+i/n, ci)
+			}
+		}
+	}
+
+	return dst
+}
+
+// Scale2x scales the image by 2 using the Scale2x algorithm by
+// Andrea Mazzoleni (also known as AdvMAME2x).
+func Scale2x(src PalettedImage) *Paletted {
+	return scalePaletted(src, 2, func(s pixelArtSource, x, y int, out []uint8) {
+		var (
+			b = s.index(x, y-1)
+			d = s.index(x-1, y)
+			e = s.index(x, y)
+			f = s.index(x+1, y)
+			h = s.index(x, y+1)
+		)
+
+		out[0], out[1], out[2], out[3] = e, e, e, e
+
+		if b != h && d != f {
+			if d == b {
+				out[0] = d
+			}
+
+			if b == f {
+				out[1] = f
+			}
+
+			if d == h {
+				out[2] = d
+			}
+
+			if h == f {
+				out[3] = f
+			}
+		}
+	})
+}
+
+// Scale3x scales the image by 3 using the Scale3x algorithm by
+// Andrea Mazzoleni (also known as AdvMAME3x).
+func Scale3x(src PalettedImage) *Paletted {
+	return scalePaletted(src, 3, func(s pixelArtSource, x, y int, out []uint8) {
+		var (
+			a = s.index(x-1, y-1)
+			b = s.index(x, y-1)
+			c = s.index(x+1, y-1)
+			d = s.index(x-1, y)
+			e = s.index(x, y)
+			f = s.index(x+1, y)
+			g = s.index(x-1, y+1)
+			h = s.index(x, y+1)
+			i = s.index(x+1, y+1)
+		)
+
+		for j := range out {
+			out[j] = e
+		}
+
+		if b == h || d == f {
+			return
+		}
+
+		if d == b {
+			out[0] = d
+		}
+
+		if (d == b && e != c) || (b == f && e != a) {
+			out[1] = b
+		}
+
+		if b == f {
+			out[2] = f
+		}
+
+		if (d == b && e != g) || (d == h && e != a) {
+			out[3] = d
+		}
+
+		if (b == f && e != i) || (h == f && e != c) {
+			out[5] = f
+		}
+
+		if d == h {
+			out[6] = d
+		}
+
+		if (d == h && e != i) || (h == f && e != g) {
+			out[7] = h
+		}
+
+		if h == f {
+			out[8] = f
+		}
+	})
+}
+
+// EPX scales the image by 2 using the Eric's Pixel Expansion algorithm
+// by Eric Johnston.
+func EPX(src PalettedImage) *Paletted {
+	return scalePaletted(src, 2, func(s pixelArtSource, x, y int, out []uint8) {
+		var (
+			a = s.index(x, y-1)
+			b = s.index(x+1, y)
+			c = s.index(x-1, y)
+			d = s.index(x, y+1)
+			p = s.index(x, y)
+		)
+
+		out[0], out[1], out[2], out[3] = p, p, p, p
+
+		// Leave the pixel as is if three or more of the neighbors are identical.
+		if (a == b && (a == c || a == d)) || (c == d && (c == a || c == b)) {
+			return
+		}
+
+		if c == a {
+			out[0] = a
+		}
+
+		if a == b {
+			out[1] = b
+		}
+
+		if d == c {
+			out[2] = c
+		}
+
+		if b == d {
+			out[3] = d
+		}
+	})
+}
+
+// Eagle scales the image by 2 using the Eagle algorithm, where each corner
+// takes the color of its three neighbors if they are all the same.
+func Eagle(src PalettedImage) *Paletted {
+	return scalePaletted(src, 2, func(s pixelArtSource, x, y int, out []uint8) {
+		var (
+			tl = s.index(x-1, y-1)
+			t  = s.index(x, y-1)
+			tr = s.index(x+1, y-1)
+			l  = s.index(x-1, y)
+			c  = s.index(x, y)
+			r  = s.index(x+1, y)
+			bl = s.index(x-1, y+1)
+			b  = s.index(x, y+1)
+			br = s.index(x+1, y+1)
+		)
+
+		out[0], out[1], out[2], out[3] = c, c, c, c
+
+		if tl == t && t == l {
+			out[0] = tl
+		}
+
+		if t == tr && tr == r {
+			out[1] = tr
+		}
+
+		if l == bl && bl == b {
+			out[2] = bl
+		}
+
+		if r == br && br == b {
+			out[3] = br
+		}
+	})
+}
+
+// pixelArtCorner is the neighborhood of a pixel, rotated so that the
+// corner being processed is the bottom right one.
+type pixelArtCorner struct {
+	s      pixelArtSource
+	x, y   int
+	rotate int
+}
+
+// at returns the color at the rotated offset dx, dy from the pixel.
+func (pc pixelArtCorner) at(dx, dy int) color.NRGBA {
+	for i := 0; i < pc.rotate; i++ {
+		dx, dy = -dy, dx
+	}
+
+	return pc.s.nrgba(pc.x+dx, pc.y+dy)
+}
+
+// local converts a position within the pixel (range 0-1) into
+// the rotated space of the corner.
+func (pc pixelArtCorner) local(u Vec) Vec {
+	u = u.Sub(V(0.5, 0.5))
+
+	for i := 0; i < pc.rotate; i++ {
+		u = V(u.Y, -u.X)
+	}
+
+	return u.Add(V(0.5, 0.5))
+}
+
+// scaleBlended creates an NRGBA image scaled by n, where blend is called
+// for each of the four corners of every pixel and returns the color
+// to mix in, and the amount to mix at a position within the pixel.
+func scaleBlended(src PalettedImage, n int, blend func(pc pixelArtCorner) (color.NRGBA, func(u Vec) float64)) *image.NRGBA {
+	s := newPixelArtSource(src)
+
+	dst := NewNRGBA(IR(0, 0, s.w*n, s.h*n))
+
+	for y := 0; y < s.h; y++ {
+		for x := 0; x < s.w; x++ {
+			var (
+				c       = s.nrgba(x, y)
+				colors  [4]color.NRGBA
+				amounts [4]func(Vec) float64
+			)
+
+			for r := 0; r < 4; r++ {
+				pc := pixelArtCorner{s, x, y, r}
+
+				if bc, amount := blend(pc); amount != nil {
+					colors[r], amounts[r] = bc, func(u Vec) float64 { return amount(pc.local(u)) }
+				}
+			}
+
+			for j := 0; j < n; j++ {
+				for i := 0; i < n; i++ {
+					u := V((float64(i)+0.5)/float64(n), (float64(j)+0.5)/float64(n))
+
+					out := c
+
+					for r, amount := range amounts {
+						if amount != nil {
+							if t := amount(u); t > 0 {
+								out = lerpNRGBA(out, colors[r], t)
+							}
+						}
+					}
+
+					dst.SetNRGBA(x*n+i, y*n+j, out)
+				}
+			}
+		}
+	}
+
+	return dst
+}
+
+// lerpNRGBA interpolates between a and b using premultiplied alpha.
+func lerpNRGBA(a, b color.NRGBA, t float64) color.NRGBA {
+	return color.NRGBAModel.Convert(SRGBColorInterpolation.Lerp(a, b, t)).(color.NRGBA)
+}
+
+// yuv returns the Y, U and V components of the color, as used by smoothScale and xBR.
+func yuv(c color.NRGBA) (y, u, v float64) {
+	r, g, b := float64(c.R), float64(c.G), float64(c.B)
+
+	y = 0.299*r + 0.587*g + 0.114*b
+	u = -0.169*r - 0.331*g + 0.5*b
+	v = 0.5*r - 0.419*g - 0.081*b
+
+	return y, u, v
+}
+
+// hqxDiff reports whether the colors are different according to the
+// thresholds used by hqx.
+func hqxDiff(a, b color.NRGBA) bool {
+	if a == b {
+		return false
+	}
+
+	ay, au, av := yuv(a)
+	by, bu, bv := yuv(b)
+
+	return math.Abs(ay-by) > 48 || math.Abs(au-bu) > 7 || math.Abs(av-bv) > 6 ||
+		math.Abs(float64(a.A)-float64(b.A)) > 16
+}
+
+// SmoothScale2x scales the image by 2 using smoothScale (not hqx).
+func SmoothScale2x(src PalettedImage) *image.NRGBA {
+	return smoothScale(src, 2)
+}
+
+// SmoothScale3x scales the image by 3 using smoothScale (not hqx).
+func SmoothScale3x(src PalettedImage) *image.NRGBA {
+	return smoothScale(src, 3)
+}
+
+// SmoothScale4x scales the image by 4 using smoothScale (not hqx).
+func SmoothScale4x(src PalettedImage) *image.NRGBA {
+	return smoothScale(src, 4)
+}
+
+// smoothScale is an edge-aware scaler that blends the corners of pixels.
+//
+// Neighbors are compared in YUV using the thresholds of hqx by Maxim Stepin,
+// and each corner of a pixel is interpolated with its neighbors based on
+// whether an edge passes through the corner.
+//
+// It is not an implementation of hqx, and the output does not match hq2x,
+// hq3x or hq4x, which select the blend of each output pixel from a table of
+// the 256 patterns of differing neighbors. The hqx scalers are not provided.
+func smoothScale(src PalettedImage, n int) *image.NRGBA {
+	return scaleBlended(src, n, func(pc pixelArtCorner) (color.NRGBA, func(u Vec) float64) {
+		var (
+			e = pc.at(0, 0)
+			f = pc.at(1, 0)
+			h = pc.at(0, 1)
+			i = pc.at(1, 1)
+		)
+
+		// Distance from the corner of the pixel (0 at the corner, 1 at the center).
+		dist := func(u Vec) float64 {
+			if u.X < 0.5 || u.Y < 0.5 {
+				return 2
+			}
+
+			return (1 - u.X) + (1 - u.Y)
+		}
+
+		switch {
+		case !hqxDiff(f, h) && hqxDiff(e, f):
+			// An edge crosses the corner, blend towards the neighbors.
+			return lerpNRGBA(f, h, 0.5), func(u Vec) float64 {
+				return Clamp(1-dist(u), 0, 1)
+			}
+		case hqxDiff(e, i) && !hqxDiff(e, f) && !hqxDiff(e, h):
+			// Only the diagonal differs, soften the corner slightly.
+			return i, func(u Vec) float64 {
+				return Clamp(0.5-dist(u)/2, 0, 1) / 2
+			}
+		}
+
+		return e, nil
+	})
+}
+
+// xbrDist is the weighted YUV distance used by xBR.
+func xbrDist(a, b color.NRGBA) float64 {
+	ay, au, av := yuv(a)
+	by, bu, bv := yuv(b)
+
+	return 48*math.Abs(ay-by) + 7*math.Abs(au-bu) + 6*math.Abs(av-bv)
+}
+
+// XBR scales the image by n (2, 3 or 4) using the second level of
+// the xBR (scale By Rules) algorithm by Hyllian.
+func XBR(src PalettedImage, n int) *image.NRGBA {
+	n = IntClamp(n, 2, 4)
+
+	return scaleBlended(src, n, func(pc pixelArtCorner) (color.NRGBA, func(u Vec) float64) {
+		//     A1 B1 C1
+		//  A0 A  B  C  C4
+		//  D0 D  E  F  F4
+		//  G0 G  H  I  I4
+		//     G5 H5 I5
+		var (
+			b  = pc.at(0, -1)
+			c  = pc.at(1, -1)
+			d  = pc.at(-1, 0)
+			e  = pc.at(0, 0)
+			f  = pc.at(1, 0)
+			g  = pc.at(-1, 1)
+			h  = pc.at(0, 1)
+			i  = pc.at(1, 1)
+			f4 = pc.at(2, 0)
+			h5 = pc.at(0, 2)
+			i4 = pc.at(2, 1)
+			i5 = pc.at(1, 2)
+		)
+
+		if e == f || e == h {
+			return e, nil
+		}
+
+		edgeR := xbrDist(e, c) + xbrDist(e, g) + xbrDist(i, f4) + xbrDist(i, h5) + 4*xbrDist(h, f)
+		edgeL := xbrDist(h, d) + xbrDist(h, i5) + xbrDist(f, i4) + xbrDist(f, b) + 4*xbrDist(e, i)
+
+		if edgeR >= edgeL {
+			return e, nil
+		}
+
+		px := h
+
+		if xbrDist(e, f) <= xbrDist(e, h) {
+			px = f
+		}
+
+		var (
+			ke  = xbrDist(f, g)
+			ki  = xbrDist(h, c)
+			ex2 = e != c && b != c
+			ex3 = e != g && d != g
+		)
+
+		var inside func(u Vec) bool
+
+		switch {
+		case ke*2 <= ki && ex3 && ke >= ki*2 && ex2:
+			inside = func(u Vec) bool { return u.X/2+u.Y > 1 || u.X+u.Y/2 > 1 }
+		case ke*2 <= ki && ex3:
+			inside = func(u Vec) bool { return u.X/2+u.Y > 1 }
+		case ke >= ki*2 && ex2:
+			inside = func(u Vec) bool { return u.X+u.Y/2 > 1 }
+		default:
+			inside = func(u Vec) bool { return u.X+u.Y > 1.5 }
+		}
+
+		// Coverage of the subpixel, based on 4×4 samples.
+		return px, func(u Vec) float64 {
+			var covered int
+
+			step := 0.25 / float64(n)
+
+			for sy := 0; sy < 4; sy++ {
+				for sx := 0; sx < 4; sx++ {
+					if inside(u.AddXY((float64(sx)-1.5)*step, (float64(sy)-1.5)*step)) {
+						covered++
+					}
+				}
+			}
+
+			return float64(covered) / 16
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package gfx
+
+import (
+	"image"
+	"testing"
+)
+
+func TestPixelArtScalers(t *testing.T) {
+	src := NewTile(Palette1Bit, 2, []uint8{
+		1, 0,
+		1, 1,
+	})
+
+	for _, tc := range []struct {
+		name string
+		fn   func(PalettedImage) *Paletted
+		n    int
+		x, y int
+	}{
+		{"Scale2x", Scale2x, 2, 2, 1},
+		{"Scale3x", Scale3x, 3, 3, 2},
+		{"EPX", EPX, 2, 2, 1},
+		{"Eagle", Eagle, 2, 2, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := tc.fn(src)
+
+			if got, want := m.Bounds(), IR(0, 0, 2*tc.n, 2*tc.n); got != want {
+				t.Fatalf("m.Bounds() = %v, want %v", got, want)
+			}
+
+			if got, want := m.Index(tc.x, tc.y), uint8(1); got != want {
+				t.Fatalf("m.Index(%d, %d) = %d, want %d", tc.x, tc.y, got, want)
+			}
+
+			if got, want := m.Index(tc.x+tc.n-1, 0), uint8(0); got != want {
+				t.Fatalf("m.Index(%d, 0) = %d, want %d", tc.x+tc.n-1, got, want)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name string
+		fn   func(PalettedImage) *image.NRGBA
+		n    int
+	}{
+		{"SmoothScale2x", SmoothScale2x, 2},
+		{"SmoothScale3x", SmoothScale3x, 3},
+		{"SmoothScale4x", SmoothScale4x, 4},
+		{"XBR", func(m PalettedImage) *image.NRGBA { return XBR(m, 3) }, 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := tc.fn(src)
+
+			if got, want := m.Bounds(), IR(0, 0, 2*tc.n, 2*tc.n); got != want {
+				t.Fatalf("m.Bounds() = %v, want %v", got, want)
+			}
+
+			if got := m.NRGBAAt(tc.n, tc.n-1); got.R == 0 {
+				t.Fatalf("m.NRGBAAt(%d, %d) = %v, want smoothed corner", tc.n, tc.n-1, got)
+			}
+
+			if got, want := m.NRGBAAt(2*tc.n-1, 0), Palette1Bit[0]; got != want {
+				t.Fatalf("m.NRGBAAt(%d, 0) = %v, want %v", 2*tc.n-1, got, want)
+			}
+		})
+	}
+}
@@ -91,6 +91,26 @@ func (p Polygon) Fill(dst draw.Image, c color.Color) (drawCount int) {
 	return drawCount
 }
 
+// FillAA fills the anti-aliased polygon on the image with the given color.
+func (p Polygon) FillAA(dst draw.Image, c color.Color) (drawCount int) {
+	if len(p) < 3 {
+		return
+	}
+
+	r := rasterBounds(p.Rect(), dst)
+
+	if r.Empty() {
+		return
+	}
+
+	rz := NewRasterizer(r)
+
+	rz.FillRule = EvenOddFillRule
+	rz.AddPolygon(p)
+
+	return rz.Draw(dst, c)
+}
+
 // Outline draws an outline of the polygon on dst.
 func (p Polygon) Outline(dst draw.Image, thickness float64, c color.Color) {
 	for i := 1; i < len(p); i++ {
@@ -119,6 +139,34 @@ func (u Vec) In(p Polygon) bool {
 	return in
 }
 
+// Winding returns the winding number of the polygon around the vector u.
+func (p Polygon) Winding(u Vec) int {
+	if len(p) < 3 {
+		return 0
+	}
+
+	var wn int
+
+	a := p[len(p)-1]
+
+	for _, b := range p {
+		switch {
+		case a.Y <= u.Y:
+			if b.Y > u.Y && b.Sub(a).Cross(u.Sub(a)) > 0 {
+				wn++
+			}
+		case b.Y <= u.Y:
+			if b.Sub(a).Cross(u.Sub(a)) < 0 {
+				wn--
+			}
+		}
+
+		a = b
+	}
+
+	return wn
+}
+
 // Points are a list of points.
 type Points []image.Point
 
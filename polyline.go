@@ -1,6 +1,11 @@
 package gfx
 
-import "math"
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
 
 // Polyline is a slice of polygons forming a line.
 type Polyline []Polygon
@@ -22,6 +27,100 @@ func NewPolyline(p Polygon, t float64) Polyline {
 	return pl
 }
 
+// Rect returns the Rect containing all of the polygons.
+func (pl Polyline) Rect() Rect {
+	var r Rect
+
+	for i, p := range pl {
+		if i == 0 {
+			r = p.Rect()
+		} else {
+			r = r.Union(p.Rect())
+		}
+	}
+
+	return r
+}
+
+// Bounds returns the bounds containing all of the polygons.
+func (pl Polyline) Bounds() image.Rectangle {
+	return pl.Rect().Bounds()
+}
+
+// Winding returns the sum of the winding numbers of all the polygons around the vector u.
+func (pl Polyline) Winding(u Vec) int {
+	var wn int
+
+	for _, p := range pl {
+		wn += p.Winding(u)
+	}
+
+	return wn
+}
+
+// EachPixel calls the provided function for each pixel inside the polygons,
+// according to the given FillRule.
+func (pl Polyline) EachPixel(fr FillRule, fn func(x, y int)) {
+	b := pl.Bounds()
+
+	for x := b.Min.X; x < b.Max.X; x++ {
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			if fr.Inside(pl.Winding(IV(x, y))) {
+				fn(x, y)
+			}
+		}
+	}
+}
+
+// Fill the polygons on the image with the given color and FillRule.
+func (pl Polyline) Fill(dst draw.Image, c color.Color, fr FillRule) (drawCount int) {
+	pl.EachPixel(fr, func(x, y int) {
+		Mix(dst, x, y, c)
+		drawCount++
+	})
+
+	return drawCount
+}
+
+// FillAA fills the anti-aliased polygons on the image with the given color and FillRule.
+func (pl Polyline) FillAA(dst draw.Image, c color.Color, fr FillRule) (drawCount int) {
+	r := rasterBounds(pl.Rect(), dst)
+
+	if r.Empty() {
+		return
+	}
+
+	rz := NewRasterizer(r)
+
+	rz.FillRule = fr
+
+	for _, p := range pl {
+		rz.AddPolygon(p)
+	}
+
+	return rz.Draw(dst, c)
+}
+
+// TrianglesData returns the polygons as triangle fans in the given color.
+//
+// Note that this only results in the correct shapes for convex polygons,
+// such as the ones returned by Stroke.
+func (pl Polyline) TrianglesData(c color.NRGBA) *TrianglesData {
+	td := &TrianglesData{}
+
+	for _, p := range pl {
+		for i := 2; i < len(p); i++ {
+			*td = append(*td,
+				Vertex{Position: p[0], Color: c},
+				Vertex{Position: p[i-1], Color: c},
+				Vertex{Position: p[i], Color: c},
+			)
+		}
+	}
+
+	return td
+}
+
 func polylineFromTo(from, to Vec, t float64) Polygon {
 	return NewPolyline(Polygon{from, to}, t)[0]
 }
@@ -0,0 +1,726 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Quantizer creates a Palette of (at most) n colors based on an image.
+type Quantizer interface {
+	Quantize(src image.Image, n int) Palette
+}
+
+// QuantizeOptions are the options shared by all of the quantizers.
+//
+// The Fixed colors are always included in the palette, and if
+// Transparent is true then the first color is ColorTransparent.
+// Both are counted towards the n colors of the palette.
+type QuantizeOptions struct {
+	Fixed       Palette
+	Transparent bool
+}
+
+// histogramColor is a color, and the number of pixels with that color.
+type histogramColor struct {
+	c [3]uint8
+	n int
+}
+
+// quantize builds the histogram of the src image and generates the rest
+// of the palette using the provided function.
+func (o QuantizeOptions) quantize(src image.Image, n int, fn func(h []histogramColor, k int) []color.NRGBA) Palette {
+	var p Palette
+
+	if o.Transparent {
+		p = append(p, ColorTransparent)
+	}
+
+	p = append(p, o.Fixed...)
+
+	k := n - len(p)
+
+	if k <= 0 {
+		return p
+	}
+
+	fixed := map[[3]uint8]bool{}
+
+	for _, c := range o.Fixed {
+		if c.A == 255 {
+			fixed[[3]uint8{c.R, c.G, c.B}] = true
+		}
+	}
+
+	counts := map[[3]uint8]int{}
+
+	b := src.Bounds()
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(src.At(x, y)).(color.NRGBA)
+
+			if c.A == 0 {
+				continue
+			}
+
+			if key := [3]uint8{c.R, c.G, c.B}; !fixed[key] {
+				counts[key]++
+			}
+		}
+	}
+
+	h := make([]histogramColor, 0, len(counts))
+
+	for c, n := range counts {
+		h = append(h, histogramColor{c, n})
+	}
+
+	sort.Slice(h, func(i, j int) bool {
+		a, b := h[i].c, h[j].c
+
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+
+		if a[1] != b[1] {
+			return a[1] < b[1]
+		}
+
+		return a[2] < b[2]
+	})
+
+	if len(h) <= k {
+		for _, hc := range h {
+			p = append(p, color.NRGBA{hc.c[0], hc.c[1], hc.c[2], 255})
+		}
+
+		return p
+	}
+
+	return append(p, fn(h, k)...)
+}
+
+// MedianCutQuantizer is a Quantizer using Heckbert's median cut algorithm.
+type MedianCutQuantizer struct {
+	QuantizeOptions
+}
+
+// Quantize creates a Palette of n colors based on the src image.
+func (mc MedianCutQuantizer) Quantize(src image.Image, n int) Palette {
+	return mc.quantize(src, n, medianCut)
+}
+
+func medianCut(h []histogramColor, k int) []color.NRGBA {
+	boxes := [][]histogramColor{h}
+
+	for len(boxes) < k {
+		bi, axis, best := -1, 0, 0
+
+		for i, box := range boxes {
+			if len(box) < 2 {
+				continue
+			}
+
+			a, r := histogramLongestAxis(box)
+
+			if r > best {
+				bi, axis, best = i, a, r
+			}
+		}
+
+		if bi < 0 {
+			break
+		}
+
+		box := boxes[bi]
+
+		sort.Slice(box, func(i, j int) bool { return box[i].c[axis] < box[j].c[axis] })
+
+		var total, sum int
+
+		for _, hc := range box {
+			total += hc.n
+		}
+
+		m := 1
+
+		for i, hc := range box[:len(box)-1] {
+			sum += hc.n
+
+			if m = i + 1; sum*2 >= total {
+				break
+			}
+		}
+
+		boxes[bi] = box[:m]
+		boxes = append(boxes, box[m:])
+	}
+
+	cs := make([]color.NRGBA, len(boxes))
+
+	for i, box := range boxes {
+		cs[i] = histogramMean(box)
+	}
+
+	return cs
+}
+
+// histogramLongestAxis returns the axis with the largest range, and the range.
+func histogramLongestAxis(h []histogramColor) (axis, r int) {
+	for a := 0; a < 3; a++ {
+		lo, hi := 255, 0
+
+		for _, hc := range h {
+			lo, hi = IntMin(lo, int(hc.c[a])), IntMax(hi, int(hc.c[a]))
+		}
+
+		if hi-lo > r {
+			axis, r = a, hi-lo
+		}
+	}
+
+	return axis, r
+}
+
+// histogramMean returns the mean color weighted by pixel count.
+func histogramMean(h []histogramColor) color.NRGBA {
+	var r, g, b, n float64
+
+	for _, hc := range h {
+		w := float64(hc.n)
+
+		r += float64(hc.c[0]) * w
+		g += float64(hc.c[1]) * w
+		b += float64(hc.c[2]) * w
+		n += w
+	}
+
+	if n == 0 {
+		return ColorTransparent
+	}
+
+	return color.NRGBA{
+		uint8(math.Round(r / n)),
+		uint8(math.Round(g / n)),
+		uint8(math.Round(b / n)),
+		255,
+	}
+}
+
+// OctreeQuantizer is a Quantizer using the octree algorithm by
+// Gervautz and Purgathofer.
+type OctreeQuantizer struct {
+	QuantizeOptions
+}
+
+// Quantize creates a Palette of n colors based on the src image.
+func (oq OctreeQuantizer) Quantize(src image.Image, n int) Palette {
+	return oq.quantize(src, n, octree)
+}
+
+type octreeNode struct {
+	children [8]*octreeNode
+	leaf     bool
+	n        int
+	sum      [3]float64
+}
+
+func octree(h []histogramColor, k int) []color.NRGBA {
+	const depth = 8
+
+	root := &octreeNode{}
+
+	// The inner nodes at each depth, starting with the root.
+	var (
+		levels = [depth][]*octreeNode{{root}}
+		leaves int
+	)
+
+	for _, hc := range h {
+		node := root
+
+		for level := 0; level < depth; level++ {
+			shift := uint(7 - level)
+
+			i := (hc.c[0]>>shift&1)<<2 | (hc.c[1]>>shift&1)<<1 | hc.c[2]>>shift&1
+
+			child := node.children[i]
+
+			if child == nil {
+				child = &octreeNode{leaf: level == depth-1}
+				node.children[i] = child
+
+				if child.leaf {
+					leaves++
+				} else {
+					levels[level+1] = append(levels[level+1], child)
+				}
+			}
+
+			node = child
+		}
+
+		w := float64(hc.n)
+
+		node.n += hc.n
+		node.sum[0] += float64(hc.c[0]) * w
+		node.sum[1] += float64(hc.c[1]) * w
+		node.sum[2] += float64(hc.c[2]) * w
+	}
+
+	// Merge the children of the deepest nodes into their parents
+	// (starting with the least common ones) until there are at most k leaves.
+	for level := depth - 1; level >= 0 && leaves > k; level-- {
+		nodes := levels[level]
+
+		for _, node := range nodes {
+			node.n = 0
+
+			for _, c := range node.children {
+				if c != nil {
+					node.n += c.n
+				}
+			}
+		}
+
+		sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].n < nodes[j].n })
+
+		for _, node := range nodes {
+			if leaves <= k {
+				break
+			}
+
+			node.n = 0
+
+			for i, c := range node.children {
+				if c == nil {
+					continue
+				}
+
+				node.n += c.n
+				node.sum[0] += c.sum[0]
+				node.sum[1] += c.sum[1]
+				node.sum[2] += c.sum[2]
+				node.children[i] = nil
+
+				leaves--
+			}
+
+			node.leaf = true
+			leaves++
+		}
+	}
+
+	var (
+		cs   []color.NRGBA
+		walk func(node *octreeNode)
+	)
+
+	walk = func(node *octreeNode) {
+		if node.leaf {
+			n := float64(node.n)
+
+			cs = append(cs, color.NRGBA{
+				uint8(math.Round(node.sum[0] / n)),
+				uint8(math.Round(node.sum[1] / n)),
+				uint8(math.Round(node.sum[2] / n)),
+				255,
+			})
+
+			return
+		}
+
+		for _, c := range node.children {
+			if c != nil {
+				walk(c)
+			}
+		}
+	}
+
+	walk(root)
+
+	return cs
+}
+
+// KMeansQuantizer is a Quantizer using k-means clustering in CIE-L*ab.
+//
+// Iterations defaults to 16 if zero.
+// The Seed is used for the k-means++ initialization.
+type KMeansQuantizer struct {
+	QuantizeOptions
+
+	Iterations int
+	Seed       int64
+}
+
+// Quantize creates a Palette of n colors based on the src image.
+func (km KMeansQuantizer) Quantize(src image.Image, n int) Palette {
+	return km.quantize(src, n, func(h []histogramColor, k int) []color.NRGBA {
+		iterations := km.Iterations
+
+		if iterations <= 0 {
+			iterations = 16
+		}
+
+		return kMeans(h, k, iterations, rand.New(rand.NewSource(km.Seed)))
+	})
+}
+
+func kMeans(h []histogramColor, k, iterations int, r *rand.Rand) []color.NRGBA {
+	ci := CIELabColorInterpolation
+
+	points := make([][4]float64, len(h))
+
+	for i, hc := range h {
+		points[i] = ci.components(color.NRGBA{hc.c[0], hc.c[1], hc.c[2], 255})
+	}
+
+	sqDist := func(a, b [4]float64) float64 {
+		return (a[0]-b[0])*(a[0]-b[0]) + (a[1]-b[1])*(a[1]-b[1]) + (a[2]-b[2])*(a[2]-b[2])
+	}
+
+	// k-means++ initialization, weighted by pixel count.
+	centers := make([][4]float64, 0, k)
+	dists := make([]float64, len(points))
+
+	for i := range dists {
+		dists[i] = math.Inf(1)
+	}
+
+	next := 0
+
+	var total float64
+
+	for _, hc := range h {
+		total += float64(hc.n)
+	}
+
+	t := r.Float64() * total
+
+	for i, hc := range h {
+		if t -= float64(hc.n); t <= 0 {
+			next = i
+			break
+		}
+	}
+
+	for len(centers) < k {
+		centers = append(centers, points[next])
+
+		var sum float64
+
+		for i, p := range points {
+			dists[i] = math.Min(dists[i], sqDist(p, points[next]))
+			sum += dists[i] * float64(h[i].n)
+		}
+
+		if sum == 0 {
+			break
+		}
+
+		t := r.Float64() * sum
+
+		for i := range points {
+			if t -= dists[i] * float64(h[i].n); t <= 0 && dists[i] > 0 {
+				next = i
+				break
+			}
+		}
+	}
+
+	assignments := make([]int, len(points))
+
+	for i := range assignments {
+		assignments[i] = -1
+	}
+
+	for iter := 0; iter < iterations; iter++ {
+		changed := false
+
+		for i, p := range points {
+			best, bestDist := 0, math.Inf(1)
+
+			for j, c := range centers {
+				if d := sqDist(p, c); d < bestDist {
+					best, bestDist = j, d
+				}
+			}
+
+			if assignments[i] != best {
+				assignments[i], changed = best, true
+			}
+		}
+
+		if !changed {
+			break
+		}
+
+		sums := make([][4]float64, len(centers))
+
+		for i, p := range points {
+			w := float64(h[i].n)
+			s := &sums[assignments[i]]
+
+			s[0] += p[0] * w
+			s[1] += p[1] * w
+			s[2] += p[2] * w
+			s[3] += w
+		}
+
+		for j, s := range sums {
+			if s[3] > 0 {
+				centers[j] = [4]float64{s[0] / s[3], s[1] / s[3], s[2] / s[3], 1}
+			}
+		}
+	}
+
+	cs := make([]color.NRGBA, len(centers))
+
+	for i, c := range centers {
+		c[3] = 1
+
+		cs[i] = color.NRGBAModel.Convert(ci.color(c)).(color.NRGBA)
+	}
+
+	return cs
+}
+
+// WuQuantizer is a Quantizer using Xiaolin Wu's color quantizer,
+// which greedily splits the RGB cube to minimize the variance.
+type WuQuantizer struct {
+	QuantizeOptions
+}
+
+// Quantize creates a Palette of n colors based on the src image.
+func (wq WuQuantizer) Quantize(src image.Image, n int) Palette {
+	return wq.quantize(src, n, wu)
+}
+
+const wuSize = 33
+
+type wuBox struct {
+	r0, r1, g0, g1, b0, b1 int
+}
+
+func (b wuBox) volume() int {
+	return (b.r1 - b.r0) * (b.g1 - b.g0) * (b.b1 - b.b0)
+}
+
+// wuMoments are the cumulative moments of the color histogram.
+type wuMoments struct {
+	wt, mr, mg, mb, m2 []float64
+}
+
+func wuIndex(r, g, b int) int {
+	return (r*wuSize+g)*wuSize + b
+}
+
+func wuVol(b wuBox, m []float64) float64 {
+	return m[wuIndex(b.r1, b.g1, b.b1)] -
+		m[wuIndex(b.r1, b.g1, b.b0)] -
+		m[wuIndex(b.r1, b.g0, b.b1)] +
+		m[wuIndex(b.r1, b.g0, b.b0)] -
+		m[wuIndex(b.r0, b.g1, b.b1)] +
+		m[wuIndex(b.r0, b.g1, b.b0)] +
+		m[wuIndex(b.r0, b.g0, b.b1)] -
+		m[wuIndex(b.r0, b.g0, b.b0)]
+}
+
+func wuBottom(b wuBox, dir int, m []float64) float64 {
+	switch dir {
+	case 0:
+		return -m[wuIndex(b.r0, b.g1, b.b1)] + m[wuIndex(b.r0, b.g1, b.b0)] + m[wuIndex(b.r0, b.g0, b.b1)] - m[wuIndex(b.r0, b.g0, b.b0)]
+	case 1:
+		return -m[wuIndex(b.r1, b.g0, b.b1)] + m[wuIndex(b.r1, b.g0, b.b0)] + m[wuIndex(b.r0, b.g0, b.b1)] - m[wuIndex(b.r0, b.g0, b.b0)]
+	default:
+		return -m[wuIndex(b.r1, b.g1, b.b0)] + m[wuIndex(b.r1, b.g0, b.b0)] + m[wuIndex(b.r0, b.g1, b.b0)] - m[wuIndex(b.r0, b.g0, b.b0)]
+	}
+}
+
+func wuTop(b wuBox, dir, pos int, m []float64) float64 {
+	switch dir {
+	case 0:
+		return m[wuIndex(pos, b.g1, b.b1)] - m[wuIndex(pos, b.g1, b.b0)] - m[wuIndex(pos, b.g0, b.b1)] + m[wuIndex(pos, b.g0, b.b0)]
+	case 1:
+		return m[wuIndex(b.r1, pos, b.b1)] - m[wuIndex(b.r1, pos, b.b0)] - m[wuIndex(b.r0, pos, b.b1)] + m[wuIndex(b.r0, pos, b.b0)]
+	default:
+		return m[wuIndex(b.r1, b.g1, pos)] - m[wuIndex(b.r1, b.g0, pos)] - m[wuIndex(b.r0, b.g1, pos)] + m[wuIndex(b.r0, b.g0, pos)]
+	}
+}
+
+func (m wuMoments) variance(b wuBox) float64 {
+	dr, dg, db := wuVol(b, m.mr), wuVol(b, m.mg), wuVol(b, m.mb)
+
+	return wuVol(b, m.m2) - (dr*dr+dg*dg+db*db)/wuVol(b, m.wt)
+}
+
+func (m wuMoments) maximize(b wuBox, dir, first, last int, whole [4]float64) (float64, int) {
+	base := [4]float64{
+		wuBottom(b, dir, m.mr),
+		wuBottom(b, dir, m.mg),
+		wuBottom(b, dir, m.mb),
+		wuBottom(b, dir, m.wt),
+	}
+
+	max, cut := 0.0, -1
+
+	for i := first; i < last; i++ {
+		half := [4]float64{
+			base[0] + wuTop(b, dir, i, m.mr),
+			base[1] + wuTop(b, dir, i, m.mg),
+			base[2] + wuTop(b, dir, i, m.mb),
+			base[3] + wuTop(b, dir, i, m.wt),
+		}
+
+		if half[3] == 0 {
+			continue
+		}
+
+		temp := (half[0]*half[0] + half[1]*half[1] + half[2]*half[2]) / half[3]
+
+		for j := range half {
+			half[j] = whole[j] - half[j]
+		}
+
+		if half[3] == 0 {
+			continue
+		}
+
+		temp += (half[0]*half[0] + half[1]*half[1] + half[2]*half[2]) / half[3]
+
+		if temp > max {
+			max, cut = temp, i
+		}
+	}
+
+	return max, cut
+}
+
+func (m wuMoments) cut(b1, b2 *wuBox) bool {
+	whole := [4]float64{wuVol(*b1, m.mr), wuVol(*b1, m.mg), wuVol(*b1, m.mb), wuVol(*b1, m.wt)}
+
+	maxR, cutR := m.maximize(*b1, 0, b1.r0+1, b1.r1, whole)
+	maxG, cutG := m.maximize(*b1, 1, b1.g0+1, b1.g1, whole)
+	maxB, cutB := m.maximize(*b1, 2, b1.b0+1, b1.b1, whole)
+
+	*b2 = *b1
+
+	switch {
+	case maxR >= maxG && maxR >= maxB:
+		if cutR < 0 {
+			return false
+		}
+
+		b2.r0, b1.r1 = cutR, cutR
+	case maxG >= maxR && maxG >= maxB:
+		b2.g0, b1.g1 = cutG, cutG
+	default:
+		b2.b0, b1.b1 = cutB, cutB
+	}
+
+	return true
+}
+
+func wu(h []histogramColor, k int) []color.NRGBA {
+	n := wuSize * wuSize * wuSize
+
+	m := wuMoments{
+		wt: make([]float64, n),
+		mr: make([]float64, n),
+		mg: make([]float64, n),
+		mb: make([]float64, n),
+		m2: make([]float64, n),
+	}
+
+	for _, hc := range h {
+		r, g, b := float64(hc.c[0]), float64(hc.c[1]), float64(hc.c[2])
+		w := float64(hc.n)
+		i := wuIndex(int(hc.c[0]>>3)+1, int(hc.c[1]>>3)+1, int(hc.c[2]>>3)+1)
+
+		m.wt[i] += w
+		m.mr[i] += r * w
+		m.mg[i] += g * w
+		m.mb[i] += b * w
+		m.m2[i] += (r*r + g*g + b*b) * w
+	}
+
+	// Compute the cumulative moments.
+	for _, mm := range [][]float64{m.wt, m.mr, m.mg, m.mb, m.m2} {
+		for r := 1; r < wuSize; r++ {
+			var area [wuSize]float64
+
+			for g := 1; g < wuSize; g++ {
+				var line float64
+
+				for b := 1; b < wuSize; b++ {
+					i := wuIndex(r, g, b)
+
+					line += mm[i]
+					area[b] += line
+					mm[i] = mm[wuIndex(r-1, g, b)] + area[b]
+				}
+			}
+		}
+	}
+
+	boxes := make([]wuBox, k)
+	vv := make([]float64, k)
+
+	boxes[0] = wuBox{0, wuSize - 1, 0, wuSize - 1, 0, wuSize - 1}
+
+	next := 0
+
+	for i := 1; i < k; i++ {
+		if m.cut(&boxes[next], &boxes[i]) {
+			vv[next], vv[i] = 0, 0
+
+			if boxes[next].volume() > 1 {
+				vv[next] = m.variance(boxes[next])
+			}
+
+			if boxes[i].volume() > 1 {
+				vv[i] = m.variance(boxes[i])
+			}
+		} else {
+			vv[next] = 0
+			i--
+		}
+
+		next = 0
+
+		temp := vv[0]
+
+		for j := 1; j <= i; j++ {
+			if vv[j] > temp {
+				temp, next = vv[j], j
+			}
+		}
+
+		if temp <= 0 {
+			k = i + 1
+			break
+		}
+	}
+
+	var cs []color.NRGBA
+
+	for _, b := range boxes[:k] {
+		w := wuVol(b, m.wt)
+
+		if w <= 0 {
+			continue
+		}
+
+		cs = append(cs, color.NRGBA{
+			uint8(math.Round(wuVol(b, m.mr) / w)),
+			uint8(math.Round(wuVol(b, m.mg) / w)),
+			uint8(math.Round(wuVol(b, m.mb) / w)),
+			255,
+		})
+	}
+
+	return cs
+}
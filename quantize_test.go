@@ -0,0 +1,99 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestQuantizers(t *testing.T) {
+	want := []color.NRGBA{
+		ColorNRGBA(200, 30, 30, 255),
+		ColorNRGBA(30, 200, 30, 255),
+		ColorNRGBA(30, 30, 200, 255),
+		ColorNRGBA(230, 230, 230, 255),
+	}
+
+	m := image.NewNRGBA(IR(0, 0, 32, 32))
+
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			c := want[(y/16)*2+x/16]
+			d := uint8((x*7 + y*3) % 5)
+
+			m.SetNRGBA(x, y, ColorNRGBA(c.R+d, c.G+d, c.B-d, 255))
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		q    Quantizer
+	}{
+		{"MedianCut", MedianCutQuantizer{}},
+		{"Octree", OctreeQuantizer{}},
+		{"KMeans", KMeansQuantizer{Seed: 1}},
+		{"Wu", WuQuantizer{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := tc.q.Quantize(m, 4)
+
+			if got, want := len(p), 4; got != want {
+				t.Fatalf("len(p) = %d, want %d", got, want)
+			}
+
+			for _, c := range want {
+				pc := p.Convert(c).(color.NRGBA)
+
+				if d := sqDiff(uint32(pc.R), uint32(c.R)) + sqDiff(uint32(pc.G), uint32(c.G)) + sqDiff(uint32(pc.B), uint32(c.B)); d > 3*8*8 {
+					t.Fatalf("p.Convert(%v) = %v", c, pc)
+				}
+			}
+		})
+	}
+}
+
+func TestQuantizersCorners(t *testing.T) {
+	// The 8 corners of the RGB cube, one in each octant of the octree root.
+	m := image.NewNRGBA(IR(0, 0, 8, 1))
+
+	for i := 0; i < 8; i++ {
+		m.SetNRGBA(i, 0, ColorNRGBA(uint8(i>>2&1*255), uint8(i>>1&1*255), uint8(i&1*255), 255))
+	}
+
+	for _, tc := range []struct {
+		name string
+		q    Quantizer
+	}{
+		{"MedianCut", MedianCutQuantizer{}},
+		{"Octree", OctreeQuantizer{}},
+		{"KMeans", KMeansQuantizer{Seed: 1}},
+		{"Wu", WuQuantizer{}},
+	} {
+		for _, k := range []int{1, 2, 4, 7} {
+			if got := len(tc.q.Quantize(m, k)); got < 1 || got > k {
+				t.Fatalf("%s: len(Quantize(m, %d)) = %d, want at most %d", tc.name, k, got, k)
+			}
+		}
+	}
+}
+
+func TestQuantizeOptions(t *testing.T) {
+	m := image.NewNRGBA(IR(0, 0, 2, 1))
+
+	m.SetNRGBA(0, 0, ColorRed)
+
+	p := MedianCutQuantizer{QuantizeOptions{
+		Fixed:       Palette{ColorBlack},
+		Transparent: true,
+	}}.Quantize(m, 8)
+
+	if got, want := len(p), 3; got != want {
+		t.Fatalf("len(p) = %d, want %d", got, want)
+	}
+
+	for i, want := range []color.NRGBA{ColorTransparent, ColorBlack, ColorRed} {
+		if got := p[i]; got != want {
+			t.Fatalf("p[%d] = %v, want %v", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"sort"
+)
+
+// FillRule decides which parts of a shape are considered to be inside of it.
+type FillRule int
+
+const (
+	// NonZeroFillRule fills all areas with a non-zero winding number.
+	NonZeroFillRule FillRule = iota
+
+	// EvenOddFillRule fills all areas with an odd winding number.
+	EvenOddFillRule
+)
+
+// Rasterizer is an anti-aliased scanline rasterizer that computes the
+// fractional coverage of each pixel by accumulating the signed area
+// covered by every added edge.
+//
+// Based on the approach used by font-rs https://github.com/raphlinus/font-rs
+type Rasterizer struct {
+	FillRule FillRule
+
+	r   image.Rectangle
+	s   int
+	acc []float64
+}
+
+// NewRasterizer creates a new Rasterizer for the given bounds.
+func NewRasterizer(r image.Rectangle) *Rasterizer {
+	s := r.Dx() + 2
+
+	return &Rasterizer{
+		r:   r,
+		s:   s,
+		acc: make([]float64, s*r.Dy()),
+	}
+}
+
+// Bounds returns the bounds of the rasterizer.
+func (rz *Rasterizer) Bounds() image.Rectangle {
+	return rz.r
+}
+
+// Reset removes all of the added edges.
+func (rz *Rasterizer) Reset() {
+	for i := range rz.acc {
+		rz.acc[i] = 0
+	}
+}
+
+// AddPolygon adds all of the edges of the (implicitly closed) polygon.
+func (rz *Rasterizer) AddPolygon(p Polygon) {
+	if len(p) < 2 {
+		return
+	}
+
+	a := p[len(p)-1]
+
+	for _, b := range p {
+		rz.AddLine(a, b)
+
+		a = b
+	}
+}
+
+// AddLine adds an edge from a to b.
+//
+// Note that the edges of each shape added to the rasterizer need to form closed contours.
+func (rz *Rasterizer) AddLine(a, b Vec) {
+	o := PV(rz.r.Min)
+	a, b = a.Sub(o), b.Sub(o)
+	w := float64(rz.r.Dx())
+
+	// Split the edge where it crosses the left and right bounds,
+	// and then clamp the parts outside of the bounds onto them.
+	ts := []float64{0, 1}
+
+	for _, x := range []float64{0, w} {
+		if (a.X < x) != (b.X < x) {
+			ts = append(ts, (x-a.X)/(b.X-a.X))
+		}
+	}
+
+	sort.Float64s(ts)
+
+	for i := 1; i < len(ts); i++ {
+		p0, p1 := a.Lerp(b, ts[i-1]), a.Lerp(b, ts[i])
+
+		p0.X = Clamp(p0.X, 0, w)
+		p1.X = Clamp(p1.X, 0, w)
+
+		rz.line(p0, p1)
+	}
+}
+
+func (rz *Rasterizer) line(p0, p1 Vec) {
+	if p0.Y == p1.Y {
+		return
+	}
+
+	dir := 1.0
+
+	if p0.Y > p1.Y {
+		dir, p0, p1 = -1, p1, p0
+	}
+
+	w, h := float64(rz.r.Dx()), rz.r.Dy()
+	dxdy := (p1.X - p0.X) / (p1.Y - p0.Y)
+	x := p0.X
+	y0 := 0
+
+	if p0.Y < 0 {
+		x -= p0.Y * dxdy
+	} else {
+		y0 = int(p0.Y)
+	}
+
+	y1 := IntMin(h, int(math.Ceil(p1.Y)))
+
+	for y := y0; y < y1; y++ {
+		row := rz.acc[y*rz.s : (y+1)*rz.s]
+
+		dy := math.Min(float64(y+1), p1.Y) - math.Max(float64(y), p0.Y)
+		xnext := Clamp(x+dxdy*dy, 0, w)
+		d := dy * dir
+
+		x0, x1 := x, xnext
+		if x0 > x1 {
+			x0, x1 = x1, x0
+		}
+
+		x0floor := math.Floor(x0)
+		x0i := int(x0floor)
+		x1ceil := math.Ceil(x1)
+		x1i := int(x1ceil)
+
+		if x1i <= x0i+1 {
+			xmf := 0.5*(x+xnext) - x0floor
+
+			row[x0i] += d - d*xmf
+			row[x0i+1] += d * xmf
+		} else {
+			s := 1 / (x1 - x0)
+			x0f := x0 - x0floor
+			a0 := 0.5 * s * (1 - x0f) * (1 - x0f)
+			x1f := x1 - x1ceil + 1
+			am := 0.5 * s * x1f * x1f
+
+			row[x0i] += d * a0
+
+			if x1i == x0i+2 {
+				row[x0i+1] += d * (1 - a0 - am)
+			} else {
+				a1 := s * (1.5 - x0f)
+				row[x0i+1] += d * (a1 - a0)
+
+				for xi := x0i + 2; xi < x1i-1; xi++ {
+					row[xi] += d * s
+				}
+
+				a2 := a1 + float64(x1i-x0i-3)*s
+				row[x1i-1] += d * (1 - a2 - am)
+			}
+
+			row[x1i] += d * am
+		}
+
+		x = xnext
+	}
+}
+
+// Coverage converts an accumulated winding value into pixel coverage.
+func (fr FillRule) Coverage(winding float64) float64 {
+	a := math.Abs(winding)
+
+	if fr == EvenOddFillRule {
+		a -= 2 * math.Floor(a/2)
+
+		if a > 1 {
+			a = 2 - a
+		}
+	}
+
+	return math.Min(a, 1)
+}
+
+// Inside returns true if the given winding number is considered inside.
+func (fr FillRule) Inside(winding int) bool {
+	if fr == EvenOddFillRule {
+		return winding%2 != 0
+	}
+
+	return winding != 0
+}
+
+// EachCoverage calls the provided function for each pixel with a coverage above zero.
+func (rz *Rasterizer) EachCoverage(fn func(x, y int, coverage float64)) {
+	w, h := rz.r.Dx(), rz.r.Dy()
+
+	for y := 0; y < h; y++ {
+		row := rz.acc[y*rz.s : (y+1)*rz.s]
+
+		var acc float64
+
+		for x := 0; x < w; x++ {
+			acc += row[x]
+
+			if c := rz.FillRule.Coverage(acc); c > 1e-9 {
+				fn(rz.r.Min.X+x, rz.r.Min.Y+y, c)
+			}
+		}
+	}
+}
+
+// Draw mixes the given color, weighted by coverage, into dst.
+func (rz *Rasterizer) Draw(dst draw.Image, c color.Color) (drawCount int) {
+	nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+
+	rz.EachCoverage(func(x, y int, coverage float64) {
+		if a := uint8(math.Round(float64(nc.A) * coverage)); a > 0 {
+			Mix(dst, x, y, ColorWithAlpha(nc, a))
+			drawCount++
+		}
+	})
+
+	return drawCount
+}
+
+// rasterBounds returns the smallest image.Rectangle containing the Rect,
+// clipped to the bounds of dst.
+func rasterBounds(r Rect, dst image.Image) image.Rectangle {
+	return image.Rect(
+		int(math.Floor(r.Min.X)),
+		int(math.Floor(r.Min.Y)),
+		int(math.Ceil(r.Max.X)),
+		int(math.Ceil(r.Max.Y)),
+	).Intersect(dst.Bounds())
+}
@@ -0,0 +1,177 @@
+package gfx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRasterizerCoverage(t *testing.T) {
+	rz := NewRasterizer(IR(0, 0, 4, 1))
+
+	rz.AddPolygon(Polygon{{0.5, 0}, {2.5, 0}, {2.5, 1}, {0.5, 1}})
+
+	got := make([]float64, 4)
+
+	rz.EachCoverage(func(x, y int, coverage float64) {
+		got[x] = coverage
+	})
+
+	for x, want := range []float64{0.5, 1, 0.5, 0} {
+		if math.Abs(got[x]-want) > 1e-9 {
+			t.Fatalf("coverage at %d = %v, want %v", x, got[x], want)
+		}
+	}
+}
+
+func TestRasterizerClipping(t *testing.T) {
+	rz := NewRasterizer(IR(0, 0, 4, 4))
+
+	rz.AddPolygon(Polygon{{-10, -10}, {10, -10}, {10, 2}, {-10, 2}})
+
+	var sum float64
+
+	rz.EachCoverage(func(x, y int, coverage float64) {
+		sum += coverage
+	})
+
+	if got, want := sum, 8.0; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("sum = %v, want %v", got, want)
+	}
+}
+
+func TestRasterizerFillRule(t *testing.T) {
+	for _, tc := range []struct {
+		fr   FillRule
+		want float64
+	}{
+		{NonZeroFillRule, 1},
+		{EvenOddFillRule, 0},
+	} {
+		rz := NewRasterizer(IR(0, 0, 4, 4))
+
+		rz.FillRule = tc.fr
+		rz.AddPolygon(Polygon{{0, 0}, {3, 0}, {3, 3}, {0, 3}})
+		rz.AddPolygon(Polygon{{1, 1}, {4, 1}, {4, 4}, {1, 4}})
+
+		var got float64
+
+		rz.EachCoverage(func(x, y int, coverage float64) {
+			if x == 2 && y == 2 {
+				got = coverage
+			}
+		})
+
+		if math.Abs(got-tc.want) > 1e-9 {
+			t.Fatalf("coverage with fill rule %d = %v, want %v", tc.fr, got, tc.want)
+		}
+	}
+}
+
+func TestPolygonFillAA(t *testing.T) {
+	dst := NewImage(4, 4, ColorBlack)
+
+	p := Polygon{{0, 0}, {4, 0}, {4, 4}}
+
+	if got, want := p.FillAA(dst, ColorWhite), 10; got != want {
+		t.Fatalf("p.FillAA(dst, ColorWhite) = %d, want %d", got, want)
+	}
+
+	if got, want := dst.RGBAAt(1, 1).R, uint8(128); got != want {
+		t.Fatalf("dst.RGBAAt(1, 1).R = %d, want %d", got, want)
+	}
+
+	if got, want := dst.RGBAAt(2, 1).R, uint8(255); got != want {
+		t.Fatalf("dst.RGBAAt(2, 1).R = %d, want %d", got, want)
+	}
+}
+
+func TestTriangleDrawAA(t *testing.T) {
+	dst := NewImage(8, 8)
+
+	tri := T(Vx(V(1, 1), ColorRed), Vx(V(7, 2), ColorRed), Vx(V(3, 7), ColorRed))
+
+	if got := tri.DrawAA(dst); got == 0 {
+		t.Fatalf("expected pixels to be drawn")
+	}
+}
+
+func TestDrawLineAA(t *testing.T) {
+	dst := NewImage(32, 32)
+
+	// A horizontal line covering y in [6.5, 10.5].
+	DrawLineAA(dst, V(4, 8.5), V(24, 8.5), 2, ColorBlue)
+
+	for _, tc := range []struct {
+		x, y int
+		want uint8
+	}{
+		{12, 8, 255},  // Inside
+		{12, 7, 255},  // Inside
+		{12, 6, 128},  // Half covered edge
+		{12, 10, 128}, // Half covered edge
+		{12, 5, 0},    // Outside
+		{12, 11, 0},   // Outside
+		{2, 8, 0},     // Before the start
+		{26, 8, 0},    // After the end
+	} {
+		if got := dst.RGBAAt(tc.x, tc.y).A; got != tc.want {
+			t.Fatalf("dst.RGBAAt(%d, %d).A = %d, want %d", tc.x, tc.y, got, tc.want)
+		}
+	}
+}
+
+func TestDrawPolygonAA(t *testing.T) {
+	dst := NewImage(16, 16)
+
+	p := Polygon{{2, 2}, {12, 2}, {12, 12}, {2, 12}}
+
+	DrawPolygonAA(dst, p, 0, ColorWhite)
+
+	for _, tc := range []struct {
+		x, y int
+		want uint8
+	}{
+		{7, 7, 255}, // Inside
+		{2, 7, 255}, // Edge on a pixel boundary
+		{1, 7, 0},   // Outside
+		{13, 13, 0}, // Outside
+	} {
+		if got := dst.RGBAAt(tc.x, tc.y).A; got != tc.want {
+			t.Fatalf("filled dst.RGBAAt(%d, %d).A = %d, want %d", tc.x, tc.y, got, tc.want)
+		}
+	}
+
+	t.Run("Outline", func(t *testing.T) {
+		dst := NewImage(16, 16)
+
+		// The outline covers one pixel on each side of the edges.
+		DrawPolygonAA(dst, Polygon{{2.5, 2.5}, {12.5, 2.5}, {12.5, 12.5}, {2.5, 12.5}}, 1, ColorWhite)
+
+		for _, tc := range []struct {
+			x, y int
+			want uint8
+		}{
+			{2, 7, 255}, // On the edge
+			{7, 2, 255}, // On the edge
+			{2, 2, 255}, // Corner
+			{1, 7, 128}, // Half covered outer edge
+			{3, 7, 128}, // Half covered inner edge
+			{7, 7, 0},   // Inside, not covered by the outline
+			{0, 7, 0},   // Outside
+		} {
+			if got := dst.RGBAAt(tc.x, tc.y).A; got != tc.want {
+				t.Fatalf("outline dst.RGBAAt(%d, %d).A = %d, want %d", tc.x, tc.y, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("Partial", func(t *testing.T) {
+		dst := NewImage(16, 16)
+
+		DrawPolylineAA(dst, Polyline{{{2, 2.5}, {12, 2.5}, {12, 12}, {2, 12}}}, 0, ColorWhite)
+
+		if got, want := dst.RGBAAt(7, 2).A, uint8(128); got != want {
+			t.Fatalf("dst.RGBAAt(7, 2).A = %d, want %d", got, want)
+		}
+	})
+}
@@ -0,0 +1,304 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// RaymarchCamera is a pinhole camera at Position looking at Target.
+//
+// A zero Up vector is treated as V3(0, 1, 0), and a zero FieldOfView as 60
+// (the vertical field of view in degrees).
+type RaymarchCamera struct {
+	Position    Vec3
+	Target      Vec3
+	Up          Vec3
+	FieldOfView float64
+}
+
+// Ray returns the unit direction of the ray through the point u
+// of the image rectangle r, as seen from the camera Position.
+func (c RaymarchCamera) Ray(u Vec, r image.Rectangle) Vec3 {
+	up := c.Up
+
+	if up == ZV3 {
+		up = V3(0, 1, 0)
+	}
+
+	fov := c.FieldOfView
+
+	if fov == 0 {
+		fov = 60
+	}
+
+	w := c.Target.Sub(c.Position).Unit()
+	s := w.Cross(up).Unit()
+	v := s.Cross(w)
+
+	h := math.Tan(fov * math.Pi / 360)
+	size := float64(r.Dy())
+
+	x := (2*(u.X-float64(r.Min.X)) - float64(r.Dx())) / size * h
+	y := (float64(r.Dy()) - 2*(u.Y-float64(r.Min.Y))) / size * h
+
+	return w.Add(s.Scaled(x)).Add(v.Scaled(y)).Unit()
+}
+
+// RaymarchLight is a directional light, shining from the Direction
+// towards the scene. A nil Color is treated as white.
+type RaymarchLight struct {
+	Direction Vec3
+	Color     color.Color
+}
+
+// Raymarcher renders a SignedDistance3Func by sphere tracing
+// rays from the Camera through each pixel of the destination image.
+//
+// Surfaces are shaded using Lambert (diffuse) and Phong (Specular, Shininess)
+// lighting for each of the Lights, where Shadows is the softness factor of
+// the soft shadows (larger is harder, 0 disables shadows) and AmbientOcclusion
+// is the strength of the ambient occlusion (0 disables ambient occlusion).
+//
+// A nil Color is treated as white. Pixels where no surface is hit are set to
+// the Background color, or left untouched if the Background is nil.
+//
+// The zero values of MaxSteps, MaxDistance and Epsilon are treated as
+// 128, 100 and 0.001 respectively.
+//
+// Output is quantized to the Palette if it is not empty, and Parallel renders
+// rows in parallel. (Only use Parallel when concurrent calls to Set on
+// different pixels of the destination image are safe)
+type Raymarcher struct {
+	Camera           RaymarchCamera
+	Lights           []RaymarchLight
+	Color            color.Color
+	Background       color.Color
+	Ambient          float64
+	Specular         float64
+	Shininess        float64
+	Shadows          float64
+	AmbientOcclusion float64
+	MaxSteps         int
+	MaxDistance      float64
+	Epsilon          float64
+	Palette          Palette
+	Parallel         bool
+}
+
+// Draw renders the signed distance function onto dst.
+func (rm Raymarcher) Draw(dst draw.Image, sdf SignedDistance3Func) {
+	rm.DrawRect(dst, dst.Bounds(), sdf)
+}
+
+// DrawRect renders the signed distance function onto the part of dst within r.
+func (rm Raymarcher) DrawRect(dst draw.Image, r image.Rectangle, sdf SignedDistance3Func) {
+	r = r.Intersect(dst.Bounds())
+
+	if r.Empty() {
+		return
+	}
+
+	var pt *PaletteTree
+
+	if len(rm.Palette) > 0 {
+		pt = rm.Palette.Tree(RGBColorMetric)
+	}
+
+	set := func(x, y int, c color.Color) {
+		if pt != nil {
+			c = pt.Convert(c)
+		}
+
+		dst.Set(x, y, c)
+	}
+
+	drawRows(r, rm.Parallel, func(y int) {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			c, ok := rm.Shade(rm.Camera.Position, rm.Camera.Ray(V(float64(x)+0.5, float64(y)+0.5), r), sdf)
+
+			switch {
+			case ok:
+				set(x, y, c)
+			case rm.Background != nil:
+				set(x, y, rm.Background)
+			}
+		}
+	})
+}
+
+// March the ray from the origin o in the (unit) direction d, returning the
+// distance to the surface, and false if no surface was hit.
+func (rm Raymarcher) March(o, d Vec3, sdf SignedDistance3Func) (float64, bool) {
+	steps, maxDist, eps := rm.limits()
+
+	t := 0.0
+
+	for i := 0; i < steps && t < maxDist; i++ {
+		h := sdf(SignedDistance3{o.Add(d.Scaled(t))})
+
+		if h < eps*MathMax(1, t) {
+			return t, true
+		}
+
+		t += h
+	}
+
+	return t, false
+}
+
+// Normal returns the surface normal at p, using central differences.
+func (rm Raymarcher) Normal(p Vec3, sdf SignedDistance3Func) Vec3 {
+	_, _, eps := rm.limits()
+
+	d := func(x, y, z float64) float64 {
+		return sdf(SignedDistance3{p.AddXYZ(x, y, z)}) - sdf(SignedDistance3{p.AddXYZ(-x, -y, -z)})
+	}
+
+	n := V3(d(eps, 0, 0), d(0, eps, 0), d(0, 0, eps))
+
+	if n == ZV3 {
+		return n
+	}
+
+	return n.Unit()
+}
+
+// Shade returns the shaded color of the surface hit by the ray from the origin
+// o in the (unit) direction d, and false if no surface was hit.
+func (rm Raymarcher) Shade(o, d Vec3, sdf SignedDistance3Func) (color.Color, bool) {
+	t, ok := rm.March(o, d, sdf)
+
+	if !ok {
+		return nil, false
+	}
+
+	p := o.Add(d.Scaled(t))
+	n := rm.Normal(p, sdf)
+
+	surface := rm.Color
+
+	if surface == nil {
+		surface = color.White
+	}
+
+	albedo := linearRGB(surface)
+
+	ao := 1.0
+
+	if rm.AmbientOcclusion > 0 {
+		ao = rm.occlusion(p, n, sdf)
+	}
+
+	light := V3(rm.Ambient, rm.Ambient, rm.Ambient).Scaled(ao)
+	specular := ZV3
+
+	for _, l := range rm.Lights {
+		ld := l.Direction.Scaled(-1).Unit()
+
+		diffuse := MathMax(0, n.Dot(ld))
+
+		if diffuse == 0 {
+			continue
+		}
+
+		lc := V3(1, 1, 1)
+
+		if l.Color != nil {
+			lc = linearRGB(l.Color)
+		}
+
+		s := 1.0
+
+		if rm.Shadows > 0 {
+			s = rm.shadow(p, n, ld, sdf)
+		}
+
+		light = light.Add(lc.Scaled(diffuse * s))
+
+		if rm.Specular > 0 {
+			// Reflection of the light direction around the normal.
+			rd := ld.Sub(n.Scaled(2 * n.Dot(ld)))
+
+			spec := math.Pow(MathMax(0, rd.Dot(d)), MathMax(1, rm.Shininess))
+
+			specular = specular.Add(lc.Scaled(rm.Specular * spec * s))
+		}
+	}
+
+	a := float64(color.NRGBA64Model.Convert(surface).(color.NRGBA64).A) / 0xFFFF
+
+	v := albedo.ScaledXYZ(light).Add(specular).Scaled(a)
+
+	return colorFromLinearPremultiplied([4]float64{v.X, v.Y, v.Z, a}), true
+}
+
+// limits returns the MaxSteps, MaxDistance and Epsilon, or their defaults.
+func (rm Raymarcher) limits() (int, float64, float64) {
+	steps, maxDist, eps := rm.MaxSteps, rm.MaxDistance, rm.Epsilon
+
+	if steps <= 0 {
+		steps = 128
+	}
+
+	if maxDist <= 0 {
+		maxDist = 100
+	}
+
+	if eps <= 0 {
+		eps = 0.001
+	}
+
+	return steps, maxDist, eps
+}
+
+// shadow returns the soft shadow (range 0-1) of the light in the
+// direction ld at p, where 0 is fully in shadow.
+func (rm Raymarcher) shadow(p, n, ld Vec3, sdf SignedDistance3Func) float64 {
+	steps, maxDist, eps := rm.limits()
+
+	o := p.Add(n.Scaled(eps * 2))
+	res := 1.0
+	t := eps * 10
+
+	for i := 0; i < steps && t < maxDist; i++ {
+		h := sdf(SignedDistance3{o.Add(ld.Scaled(t))})
+
+		if h < eps {
+			return 0
+		}
+
+		res = MathMin(res, rm.Shadows*h/t)
+		t += h
+	}
+
+	return Clamp(res, 0, 1)
+}
+
+// occlusion returns the ambient occlusion (range 0-1) at p
+// with the normal n, where 0 is fully occluded.
+func (rm Raymarcher) occlusion(p, n Vec3, sdf SignedDistance3Func) float64 {
+	occ, w := 0.0, 1.0
+
+	for i := 1; i <= 5; i++ {
+		h := 0.01 + 0.12*float64(i)
+		d := sdf(SignedDistance3{p.Add(n.Scaled(h))})
+
+		occ += (h - d) * w
+		w *= 0.95
+	}
+
+	return Clamp(1-rm.AmbientOcclusion*occ, 0, 1)
+}
+
+// linearRGB returns the (non premultiplied) linear light components of c.
+func linearRGB(c color.Color) Vec3 {
+	n := color.NRGBA64Model.Convert(c).(color.NRGBA64)
+
+	return V3(
+		sRGBToLinear(float64(n.R)/0xFFFF),
+		sRGBToLinear(float64(n.G)/0xFFFF),
+		sRGBToLinear(float64(n.B)/0xFFFF),
+	)
+}
@@ -0,0 +1,107 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestRaymarcherMarch(t *testing.T) {
+	var rm Raymarcher
+
+	sphere := func(sd SignedDistance3) float64 { return sd.Sphere(1) }
+
+	d, ok := rm.March(V3(0, 0, -5), V3(0, 0, 1), sphere)
+
+	if !ok {
+		t.Fatalf("expected ray to hit the sphere")
+	}
+
+	if MathAbs(d-4) > 0.01 {
+		t.Fatalf("d = %v, want 4", d)
+	}
+
+	if _, ok := rm.March(V3(0, 0, -5), V3(0, 1, 0), sphere); ok {
+		t.Fatalf("expected ray to miss the sphere")
+	}
+
+	n := rm.Normal(V3(0, 1, 0), sphere)
+
+	if MathAbs(n.X) > 1e-6 || MathAbs(n.Y-1) > 1e-6 || MathAbs(n.Z) > 1e-6 {
+		t.Fatalf("n = %v, want %v", n, V3(0, 1, 0))
+	}
+}
+
+func TestRaymarcherDraw(t *testing.T) {
+	scene := func(sd SignedDistance3) float64 {
+		return sd.OpUnion(sd.Sphere(1), sd.Plane(V3(0, 1, 0), 1))
+	}
+
+	rm := Raymarcher{
+		Camera: RaymarchCamera{
+			Position: V3(0, 0.5, -5),
+			Target:   V3(0, 0, 0),
+		},
+		Lights: []RaymarchLight{
+			{Direction: V3(-1, -2, 1)},
+		},
+		Color:            ColorRed,
+		Background:       ColorBlack,
+		Ambient:          0.1,
+		Specular:         0.5,
+		Shininess:        16,
+		Shadows:          8,
+		AmbientOcclusion: 1,
+		Parallel:         true,
+	}
+
+	dst := NewImage(32, 32, ColorTransparent)
+
+	rm.Draw(dst, scene)
+
+	if got, want := color.NRGBAModel.Convert(dst.At(16, 0)).(color.NRGBA), ColorBlack; got != want {
+		t.Fatalf("background = %v, want %v", got, want)
+	}
+
+	c := color.NRGBAModel.Convert(dst.At(12, 12)).(color.NRGBA)
+
+	if c.R < 100 || c.G > 100 || c.A != 255 {
+		t.Fatalf("sphere = %v, want lit red", c)
+	}
+
+	// The sphere casts a shadow onto the plane below it.
+	lit := color.NRGBAModel.Convert(dst.At(2, 30)).(color.NRGBA)
+	shadow := color.NRGBAModel.Convert(dst.At(16, 21)).(color.NRGBA)
+
+	if shadow.R >= lit.R {
+		t.Fatalf("shadow = %v, lit = %v, want darker shadow", shadow, lit)
+	}
+
+	t.Run("Palette", func(t *testing.T) {
+		p := Palette{ColorBlack, ColorRed, ColorWhite}
+
+		rm.Palette = p
+
+		dst := NewImage(16, 16, ColorTransparent)
+
+		rm.DrawRect(dst, image.Rect(0, 0, 16, 8), scene)
+
+		for y := 0; y < 16; y++ {
+			for x := 0; x < 16; x++ {
+				c := dst.At(x, y)
+
+				if y >= 8 {
+					if got := color.NRGBAModel.Convert(c); got != ColorTransparent {
+						t.Fatalf("outside rect (%d, %d) = %v", x, y, got)
+					}
+
+					continue
+				}
+
+				if got := p.Convert(c); got != color.NRGBAModel.Convert(c) {
+					t.Fatalf("(%d, %d) = %v, not in the palette", x, y, c)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,245 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// ResampleFilter is a separable filter used when resampling images.
+//
+// The Kernel is zero outside of [-Support, Support].
+type ResampleFilter struct {
+	Support float64
+	Kernel  func(x float64) float64
+}
+
+// Resampling filters.
+var (
+	BoxResampleFilter = ResampleFilter{0.5, func(x float64) float64 {
+		if x >= -0.5 && x < 0.5 {
+			return 1
+		}
+
+		return 0
+	}}
+
+	BilinearResampleFilter = ResampleFilter{1, func(x float64) float64 {
+		return math.Max(0, 1-math.Abs(x))
+	}}
+
+	CatmullRomResampleFilter = ResampleFilter{2, bicubicKernel(0, 0.5)}
+
+	MitchellResampleFilter = ResampleFilter{2, bicubicKernel(1.0/3, 1.0/3)}
+
+	Lanczos2ResampleFilter = ResampleFilter{2, lanczosKernel(2)}
+
+	Lanczos3ResampleFilter = ResampleFilter{3, lanczosKernel(3)}
+)
+
+// bicubicKernel returns the Mitchell-Netravali cubic with parameters B and C.
+func bicubicKernel(b, c float64) func(float64) float64 {
+	return func(x float64) float64 {
+		x = math.Abs(x)
+
+		switch {
+		case x < 1:
+			return ((12-9*b-6*c)*x*x*x + (-18+12*b+6*c)*x*x + (6 - 2*b)) / 6
+		case x < 2:
+			return ((-b-6*c)*x*x*x + (6*b+30*c)*x*x + (-12*b-48*c)*x + (8*b + 24*c)) / 6
+		default:
+			return 0
+		}
+	}
+}
+
+// lanczosKernel returns the Lanczos windowed sinc with the given number of lobes.
+func lanczosKernel(a float64) func(float64) float64 {
+	sinc := func(x float64) float64 {
+		if x == 0 {
+			return 1
+		}
+
+		return math.Sin(math.Pi*x) / (math.Pi * x)
+	}
+
+	return func(x float64) float64 {
+		if x <= -a || x >= a {
+			return 0
+		}
+
+		return sinc(x) * sinc(x/a)
+	}
+}
+
+// NewResampledImage returns a new image with the provided dimensions,
+// resampled using the filter.
+func NewResampledImage(src image.Image, w, h int, f ResampleFilter) image.Image {
+	dst := NewImage(w, h)
+
+	ResampleImage(dst, src, f)
+
+	return dst
+}
+
+// NewResampledRGBA returns a new RGBA image with the provided dimensions,
+// resampled using the filter.
+func NewResampledRGBA(src image.Image, r image.Rectangle, f ResampleFilter) *image.RGBA {
+	dst := NewRGBA(r)
+
+	ResampleImage(dst, src, f)
+
+	return dst
+}
+
+// NewScaledResampledRGBA returns a new RGBA image scaled by the provided
+// scaling factor, resampled using the filter.
+func NewScaledResampledRGBA(src image.Image, s float64, f ResampleFilter) *image.RGBA {
+	b := src.Bounds()
+
+	if b.Empty() {
+		return &image.RGBA{}
+	}
+
+	return NewResampledRGBA(src, IR(0, 0, int(float64(b.Dx())*s), int(float64(b.Dy())*s)), f)
+}
+
+// ResampleImage resamples src onto dst using the filter, first horizontally
+// and then vertically. The filtering is done in premultiplied linear light.
+func ResampleImage(dst draw.Image, src image.Image, f ResampleFilter) {
+	db, sb := dst.Bounds(), src.Bounds()
+
+	dw, dh, sw, sh := db.Dx(), db.Dy(), sb.Dx(), sb.Dy()
+
+	if dw <= 0 || dh <= 0 || sw <= 0 || sh <= 0 {
+		return
+	}
+
+	// Premultiplied linear light pixels of the src image.
+	pix := make([][4]float64, sw*sh)
+
+	for y := 0; y < sh; y++ {
+		for x := 0; x < sw; x++ {
+			pix[y*sw+x] = linearPremultiplied(src.At(sb.Min.X+x, sb.Min.Y+y))
+		}
+	}
+
+	// Horizontal pass into a dw × sh buffer.
+	tmp := make([][4]float64, dw*sh)
+
+	for x, rw := range f.weights(dw, sw) {
+		for y := 0; y < sh; y++ {
+			var sum [4]float64
+
+			for i, w := range rw.w {
+				p := pix[y*sw+rw.start+i]
+
+				sum[0] += p[0] * w
+				sum[1] += p[1] * w
+				sum[2] += p[2] * w
+				sum[3] += p[3] * w
+			}
+
+			tmp[y*dw+x] = sum
+		}
+	}
+
+	// Vertical pass into the dst image.
+	for y, rw := range f.weights(dh, sh) {
+		for x := 0; x < dw; x++ {
+			var sum [4]float64
+
+			for i, w := range rw.w {
+				p := tmp[(rw.start+i)*dw+x]
+
+				sum[0] += p[0] * w
+				sum[1] += p[1] * w
+				sum[2] += p[2] * w
+				sum[3] += p[3] * w
+			}
+
+			dst.Set(db.Min.X+x, db.Min.Y+y, colorFromLinearPremultiplied(sum))
+		}
+	}
+}
+
+// resampleWeights are the normalized filter weights for the
+// source pixels starting at start.
+type resampleWeights struct {
+	start int
+	w     []float64
+}
+
+// weights returns the weights for each of the dn destination pixels
+// sampling sn source pixels. The filter is widened when downsampling.
+func (f ResampleFilter) weights(dn, sn int) []resampleWeights {
+	scale := float64(sn) / float64(dn)
+	fs := math.Max(1, scale)
+	support := f.Support * fs
+
+	rws := make([]resampleWeights, dn)
+
+	for i := range rws {
+		center := (float64(i) + 0.5) * scale
+
+		start := IntMax(0, int(math.Floor(center-support)))
+		end := IntMin(sn, int(math.Ceil(center+support)))
+
+		w := make([]float64, end-start)
+
+		var sum float64
+
+		for j := range w {
+			w[j] = f.Kernel((float64(start+j) + 0.5 - center) / fs)
+			sum += w[j]
+		}
+
+		if sum == 0 {
+			// Fall back to the nearest source pixel.
+			n := IntClamp(int(center), 0, sn-1)
+
+			rws[i] = resampleWeights{n, []float64{1}}
+
+			continue
+		}
+
+		for j := range w {
+			w[j] /= sum
+		}
+
+		rws[i] = resampleWeights{start, w}
+	}
+
+	return rws
+}
+
+// linearPremultiplied converts c into premultiplied linear light components.
+func linearPremultiplied(c color.Color) [4]float64 {
+	n := color.NRGBA64Model.Convert(c).(color.NRGBA64)
+
+	a := float64(n.A) / 0xFFFF
+
+	return [4]float64{
+		sRGBToLinear(float64(n.R)/0xFFFF) * a,
+		sRGBToLinear(float64(n.G)/0xFFFF) * a,
+		sRGBToLinear(float64(n.B)/0xFFFF) * a,
+		a,
+	}
+}
+
+// colorFromLinearPremultiplied converts premultiplied linear light
+// components into a color.NRGBA64.
+func colorFromLinearPremultiplied(v [4]float64) color.NRGBA64 {
+	a := Clamp(v[3], 0, 1)
+
+	if a == 0 {
+		return color.NRGBA64{}
+	}
+
+	c := func(v float64) uint16 {
+		return uint16(math.Round(linearToSRGB(Clamp(v/a, 0, 1)) * 0xFFFF))
+	}
+
+	return color.NRGBA64{c(v[0]), c(v[1]), c(v[2]), uint16(math.Round(a * 0xFFFF))}
+}
@@ -0,0 +1,67 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestResampleImage(t *testing.T) {
+	checker := NewNRGBA(IR(0, 0, 4, 4))
+
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			if (x+y)%2 == 0 {
+				checker.SetNRGBA(x, y, ColorWhite)
+			} else {
+				checker.SetNRGBA(x, y, ColorBlack)
+			}
+		}
+	}
+
+	t.Run("LinearLight", func(t *testing.T) {
+		m := NewResampledRGBA(checker, IR(0, 0, 2, 2), BoxResampleFilter)
+
+		if got, want := m.RGBAAt(1, 1), (color.RGBA{188, 188, 188, 255}); got != want {
+			t.Fatalf("m.RGBAAt(1, 1) = %v, want %v", got, want)
+		}
+	})
+
+	uniform := NewImage(5, 5, ColorNRGBA(50, 100, 150, 255))
+
+	for _, tc := range []struct {
+		name string
+		f    ResampleFilter
+	}{
+		{"Box", BoxResampleFilter},
+		{"Bilinear", BilinearResampleFilter},
+		{"CatmullRom", CatmullRomResampleFilter},
+		{"Mitchell", MitchellResampleFilter},
+		{"Lanczos2", Lanczos2ResampleFilter},
+		{"Lanczos3", Lanczos3ResampleFilter},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, s := range []float64{0.5, 3} {
+				m := NewScaledResampledRGBA(uniform, s, tc.f)
+
+				for _, p := range []image.Point{{0, 0}, {1, 1}, {m.Bounds().Dx() - 1, 0}} {
+					if got, want := m.RGBAAt(p.X, p.Y), (color.RGBA{50, 100, 150, 255}); got != want {
+						t.Fatalf("m.RGBAAt(%v) = %v, want %v", p, got, want)
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("Premultiplied", func(t *testing.T) {
+		src := NewNRGBA(IR(0, 0, 2, 1))
+
+		src.SetNRGBA(0, 0, ColorNRGBA(255, 0, 0, 255))
+
+		m := NewResampledImage(src, 1, 1, BilinearResampleFilter)
+
+		if got, want := color.NRGBAModel.Convert(m.At(0, 0)), ColorNRGBA(255, 0, 0, 128); got != want {
+			t.Fatalf("m.At(0, 0) = %v, want %v", got, want)
+		}
+	})
+}
@@ -6,6 +6,8 @@ import (
 )
 
 // NewResizedImage returns a new image with the provided dimensions.
+//
+// Nearest neighbor scaling is used, see NewResampledImage for other filters.
 func NewResizedImage(src image.Image, w, h int) image.Image {
 	dst := NewImage(w, h)
 
@@ -1,5 +1,7 @@
 package gfx
 
+import "math"
+
 // SignedDistance holds 2D signed distance functions based on
 // https://iquilezles.org/www/articles/distfunctions2d/distfunctions2d.htm
 type SignedDistance struct {
@@ -73,6 +75,493 @@ func (sd SignedDistance) IsoscelesTriangle(q Vec) float64 {
 	return -MathSqrt(d.X) * Sign(d.Y)
 }
 
+// RoundedBox primitive with the corner radii r in the order: bottom right,
+// top right, bottom left and top left (with the Y axis pointing down).
+func (sd SignedDistance) RoundedBox(b Vec, r [4]float64) float64 {
+	p := sd.Vec
+
+	rx, ry := r[0], r[1]
+
+	if p.X <= 0 {
+		rx, ry = r[2], r[3]
+	}
+
+	if p.Y <= 0 {
+		rx = ry
+	}
+
+	q := p.Abs().Sub(b).AddXY(rx, rx)
+
+	return MathMin(MathMax(q.X, q.Y), 0) + q.Max(ZV).Len() - rx
+}
+
+// OrientedBox primitive from a to b with the thickness th.
+// If a and b are equal, the box is along the X axis.
+func (sd SignedDistance) OrientedBox(a, b Vec, th float64) float64 {
+	l := b.Sub(a).Len()
+	d := V(1, 0)
+
+	if l > 0 {
+		d = b.Sub(a).Scaled(1 / l)
+	}
+
+	q := sd.Sub(a.Add(b).Scaled(0.5))
+
+	q = V(d.X*q.X+d.Y*q.Y, -d.Y*q.X+d.X*q.Y)
+	q = q.Abs().Sub(V(l, th).Scaled(0.5))
+
+	return q.Max(ZV).Len() + MathMin(MathMax(q.X, q.Y), 0)
+}
+
+// Trapezoid primitive with the bottom radius r1, top radius r2 and half height he.
+func (sd SignedDistance) Trapezoid(r1, r2, he float64) float64 {
+	p := sd.Vec
+
+	k1 := V(r2, he)
+	k2 := V(r2-r1, 2*he)
+
+	p.X = MathAbs(p.X)
+
+	r := r2
+
+	if p.Y < 0 {
+		r = r1
+	}
+
+	ca := V(p.X-MathMin(p.X, r), MathAbs(p.Y)-he)
+	cb := p.Sub(k1).Add(k2.Scaled(Clamp(k1.Sub(p).Dot(k2)/k2.Dot(k2), 0, 1)))
+
+	s := 1.0
+
+	if cb.X < 0 && ca.Y < 0 {
+		s = -1
+	}
+
+	return s * MathSqrt(MathMin(ca.Dot(ca), cb.Dot(cb)))
+}
+
+// Parallelogram primitive with the half width wi, half height he and skew sk.
+func (sd SignedDistance) Parallelogram(wi, he, sk float64) float64 {
+	p := sd.Vec
+	e := V(sk, he)
+
+	if p.Y < 0 {
+		p = p.Scaled(-1)
+	}
+
+	w := p.Sub(e)
+	w.X -= Clamp(w.X, -wi, wi)
+
+	d := V(w.Dot(w), -w.Y)
+
+	s := p.X*e.Y - p.Y*e.X
+
+	if s < 0 {
+		p = p.Scaled(-1)
+	}
+
+	v := p.Sub(V(wi, 0))
+	v = v.Sub(e.Scaled(Clamp(v.Dot(e)/e.Dot(e), -1, 1)))
+
+	d = d.Min(V(v.Dot(v), wi*he-MathAbs(s)))
+
+	return MathSqrt(d.X) * Sign(-d.Y)
+}
+
+// Triangle primitive with the corners p0, p1 and p2.
+func (sd SignedDistance) Triangle(p0, p1, p2 Vec) float64 {
+	p := sd.Vec
+
+	e0, e1, e2 := p1.Sub(p0), p2.Sub(p1), p0.Sub(p2)
+	v0, v1, v2 := p.Sub(p0), p.Sub(p1), p.Sub(p2)
+
+	pq0 := v0.Sub(e0.Scaled(segmentProjection(v0, e0)))
+	pq1 := v1.Sub(e1.Scaled(segmentProjection(v1, e1)))
+	pq2 := v2.Sub(e2.Scaled(segmentProjection(v2, e2)))
+
+	s := Sign(e0.X*e2.Y - e0.Y*e2.X)
+
+	d := V(pq0.Dot(pq0), s*(v0.X*e0.Y-v0.Y*e0.X)).
+		Min(V(pq1.Dot(pq1), s*(v1.X*e1.Y-v1.Y*e1.X))).
+		Min(V(pq2.Dot(pq2), s*(v2.X*e2.Y-v2.Y*e2.X)))
+
+	// The corners are on a line, so there is no inside.
+	if s == 0 {
+		return MathSqrt(d.X)
+	}
+
+	return -MathSqrt(d.X) * Sign(d.Y)
+}
+
+// segmentProjection returns the position (range [0, 1]) along the edge e of
+// the closest point to w, where w is relative to the start of the edge.
+// Zero-length edges return 0.
+func segmentProjection(w, e Vec) float64 {
+	ee := e.Dot(e)
+
+	if ee == 0 {
+		return 0
+	}
+
+	return Clamp(w.Dot(e)/ee, 0, 1)
+}
+
+// Pentagon primitive with the inradius r.
+func (sd SignedDistance) Pentagon(r float64) float64 {
+	const kx, ky, kz = 0.809016994, 0.587785252, 0.726542528
+
+	p := sd.Vec
+
+	p.X = MathAbs(p.X)
+	p = p.Sub(V(-kx, ky).Scaled(2 * MathMin(V(-kx, ky).Dot(p), 0)))
+	p = p.Sub(V(kx, ky).Scaled(2 * MathMin(V(kx, ky).Dot(p), 0)))
+	p = p.Sub(V(Clamp(p.X, -r*kz, r*kz), r))
+
+	return p.Len() * Sign(p.Y)
+}
+
+// Hexagon primitive with the inradius r.
+func (sd SignedDistance) Hexagon(r float64) float64 {
+	const kx, ky, kz = -0.866025404, 0.5, 0.577350269
+
+	p := sd.Abs()
+
+	p = p.Sub(V(kx, ky).Scaled(2 * MathMin(V(kx, ky).Dot(p), 0)))
+	p = p.Sub(V(Clamp(p.X, -kz*r, kz*r), r))
+
+	return p.Len() * Sign(p.Y)
+}
+
+// Octagon primitive with the inradius r.
+func (sd SignedDistance) Octagon(r float64) float64 {
+	const kx, ky, kz = -0.9238795325, 0.3826834323, 0.4142135623
+
+	p := sd.Abs()
+
+	p = p.Sub(V(kx, ky).Scaled(2 * MathMin(V(kx, ky).Dot(p), 0)))
+	p = p.Sub(V(-kx, ky).Scaled(2 * MathMin(V(-kx, ky).Dot(p), 0)))
+	p = p.Sub(V(Clamp(p.X, -kz*r, kz*r), r))
+
+	return p.Len() * Sign(p.Y)
+}
+
+// Star primitive with the radius r, n points and the
+// m (range 2-n) determining how pointy the star is.
+func (sd SignedDistance) Star(r float64, n int, m float64) float64 {
+	an := math.Pi / float64(n)
+	en := math.Pi / m
+
+	acs := V(MathCos(an), MathSin(an))
+	ecs := V(MathCos(en), MathSin(en))
+
+	bn := math.Atan2(sd.X, sd.Y)
+	bn = bn - 2*an*MathFloor(bn/(2*an)) - an
+
+	p := V(MathCos(bn), MathAbs(MathSin(bn))).Scaled(sd.Len())
+
+	p = p.Sub(acs.Scaled(r))
+	p = p.Add(ecs.Scaled(Clamp(-p.Dot(ecs), 0, r*acs.Y/ecs.Y)))
+
+	return p.Len() * Sign(p.X)
+}
+
+// Pie primitive with the aperture angle (on each side of the Y axis) and radius r.
+func (sd SignedDistance) Pie(angle, r float64) float64 {
+	c := V(MathSin(angle), MathCos(angle))
+	p := sd.Vec
+
+	p.X = MathAbs(p.X)
+
+	l := p.Len() - r
+	m := p.Sub(c.Scaled(Clamp(p.Dot(c), 0, r))).Len()
+
+	return MathMax(l, m*Sign(c.Y*p.X-c.X*p.Y))
+}
+
+// Arc primitive with the aperture angle (on each side of the Y axis),
+// radius ra and thickness rb.
+func (sd SignedDistance) Arc(angle, ra, rb float64) float64 {
+	sc := V(MathSin(angle), MathCos(angle))
+	p := sd.Vec
+
+	p.X = MathAbs(p.X)
+
+	if sc.Y*p.X > sc.X*p.Y {
+		return p.Sub(sc.Scaled(ra)).Len() - rb
+	}
+
+	return MathAbs(p.Len()-ra) - rb
+}
+
+// Ring primitive with the aperture angle (on each side of the Y axis),
+// radius r and thickness th.
+func (sd SignedDistance) Ring(angle, r, th float64) float64 {
+	n := V(MathCos(angle), MathSin(angle))
+	p := sd.Vec
+
+	p.X = MathAbs(p.X)
+	p = V(n.X*p.X-n.Y*p.Y, n.Y*p.X+n.X*p.Y)
+
+	return MathMax(
+		MathAbs(p.Len()-r)-th*0.5,
+		V(p.X, MathMax(0, MathAbs(r-p.Y)-th*0.5)).Len()*Sign(p.X),
+	)
+}
+
+// Horseshoe primitive with the aperture angle, radius r and the
+// length and thickness of the legs in w.
+func (sd SignedDistance) Horseshoe(angle, r float64, w Vec) float64 {
+	c := V(MathCos(angle), MathSin(angle))
+	p := sd.Vec
+
+	p.X = MathAbs(p.X)
+
+	l := p.Len()
+
+	p = V(-c.X*p.X+c.Y*p.Y, c.Y*p.X+c.X*p.Y)
+
+	x, y := p.X, p.Y
+
+	if !(p.Y > 0 || p.X > 0) {
+		x = l * Sign(-c.X)
+	}
+
+	if p.X <= 0 {
+		y = l
+	}
+
+	p = V(x, MathAbs(y-r)).Sub(w)
+
+	return p.Max(ZV).Len() + MathMin(0, MathMax(p.X, p.Y))
+}
+
+// Vesica primitive formed by two circles of radius r, at distance d from the center.
+func (sd SignedDistance) Vesica(r, d float64) float64 {
+	p := sd.Abs()
+	b := MathSqrt(r*r - d*d)
+
+	if (p.Y-b)*d > p.X*b {
+		return p.Sub(V(0, b)).Len()
+	}
+
+	return p.Sub(V(-d, 0)).Len() - r
+}
+
+// Egg primitive with the radius ra and the radius rb of the smaller end.
+func (sd SignedDistance) Egg(ra, rb float64) float64 {
+	k := MathSqrt(3)
+	p := sd.Vec
+
+	p.X = MathAbs(p.X)
+
+	r := ra - rb
+
+	switch {
+	case p.Y < 0:
+		return p.Len() - r - rb
+	case k*(p.X+r) < p.Y:
+		return V(p.X, p.Y-k*r).Len() - rb
+	default:
+		return V(p.X+r, p.Y).Len() - 2*r - rb
+	}
+}
+
+// Heart primitive, about one unit in size.
+func (sd SignedDistance) Heart() float64 {
+	p := sd.Vec
+
+	p.X = MathAbs(p.X)
+
+	if p.Y+p.X > 1 {
+		return p.Sub(V(0.25, 0.75)).Len() - MathSqrt(2)/4
+	}
+
+	a := p.Sub(V(0, 1))
+	b := p.Sub(V(1, 1).Scaled(0.5 * MathMax(p.X+p.Y, 0)))
+
+	return MathSqrt(MathMin(a.Dot(a), b.Dot(b))) * Sign(p.X-p.Y)
+}
+
+// Cross primitive with the size b of the arms, and the rounding r.
+func (sd SignedDistance) Cross(b Vec, r float64) float64 {
+	p := sd.Abs()
+
+	if p.Y > p.X {
+		p = V(p.Y, p.X)
+	}
+
+	q := p.Sub(b)
+	k := MathMax(q.Y, q.X)
+
+	w := q
+
+	if k <= 0 {
+		w = V(b.Y-p.X, -k)
+	}
+
+	return Sign(k)*w.Max(ZV).Len() + r
+}
+
+// Ellipse primitive with the radii ab.
+func (sd SignedDistance) Ellipse(ab Vec) float64 {
+	if ab.X == ab.Y {
+		return sd.Circle(ab.X)
+	}
+
+	p := sd.Abs()
+
+	if p.X > p.Y {
+		p, ab = V(p.Y, p.X), V(ab.Y, ab.X)
+	}
+
+	l := ab.Y*ab.Y - ab.X*ab.X
+
+	m := ab.X * p.X / l
+	n := ab.Y * p.Y / l
+	m2, n2 := m*m, n*n
+
+	c := (m2 + n2 - 1) / 3
+	c3 := c * c * c
+
+	q := c3 + m2*n2*2
+	d := c3 + m2*n2
+	g := m + m*n2
+
+	var co float64
+
+	if d < 0 {
+		h := math.Acos(q/c3) / 3
+		s := MathCos(h)
+		t := MathSin(h) * MathSqrt(3)
+		rx := MathSqrt(-c*(s+t+2) + m2)
+		ry := MathSqrt(-c*(s-t+2) + m2)
+
+		co = (ry + Sign(l)*rx + MathAbs(g)/(rx*ry) - m) / 2
+	} else {
+		h := 2 * m * n * MathSqrt(d)
+		s := math.Cbrt(q + h)
+		u := math.Cbrt(q - h)
+		rx := -s - u - c*4 + 2*m2
+		ry := (s - u) * MathSqrt(3)
+		rm := MathSqrt(rx*rx + ry*ry)
+
+		co = (ry/MathSqrt(rm-rx) + 2*g/rm - m) / 2
+	}
+
+	r := ab.ScaledXY(V(co, MathSqrt(1-co*co)))
+
+	return r.Sub(p).Len() * Sign(p.Y-r.Y)
+}
+
+// Parabola primitive y = k*x².
+func (sd SignedDistance) Parabola(k float64) float64 {
+	pos := sd.Vec
+
+	pos.X = MathAbs(pos.X)
+
+	ik := 1 / k
+	p := ik * (pos.Y - 0.5*ik) / 3
+	q := 0.25 * ik * ik * pos.X
+	h := q*q - p*p*p
+	r := MathSqrt(MathAbs(h))
+
+	var x float64
+
+	if h > 0 {
+		x = math.Cbrt(q+r) - math.Cbrt(MathAbs(q-r))*Sign(r-q)
+	} else {
+		x = 2 * MathCos(math.Atan2(r, q)/3) * MathSqrt(p)
+	}
+
+	d := pos.Sub(V(x, k*x*x)).Len()
+
+	if pos.Y > k*pos.X*pos.X {
+		return -d
+	}
+
+	return d
+}
+
+// QuadraticBezier primitive (unsigned) for the curve from p0 to p2 with the control point p1.
+func (sd SignedDistance) QuadraticBezier(p0, p1, p2 Vec) float64 {
+	a := p1.Sub(p0)
+	b := p0.Sub(p1.Scaled(2)).Add(p2)
+	c := a.Scaled(2)
+	d := p0.Sub(sd.Vec)
+
+	if b.Dot(b) < 1e-12 {
+		return sd.Line(p0, p2)
+	}
+
+	kk := 1 / b.Dot(b)
+	kx := kk * a.Dot(b)
+	ky := kk * (2*a.Dot(a) + d.Dot(b)) / 3
+	kz := kk * d.Dot(a)
+
+	p := ky - kx*kx
+	p3 := p * p * p
+	q := kx*(2*kx*kx-3*ky) + kz
+	h := q*q + 4*p3
+
+	dist := func(t float64) float64 {
+		v := d.Add(c.Add(b.Scaled(t)).Scaled(t))
+
+		return v.Dot(v)
+	}
+
+	if h >= 0 {
+		h = MathSqrt(h)
+
+		t := Clamp(math.Cbrt((h-q)/2)+math.Cbrt((-h-q)/2)-kx, 0, 1)
+
+		return MathSqrt(dist(t))
+	}
+
+	z := MathSqrt(-p)
+	v := math.Acos(q/(p*z*2)) / 3
+	m := MathCos(v)
+	n := MathSin(v) * 1.732050808
+
+	// The third root can not be the closest.
+	return MathSqrt(MathMin(
+		dist(Clamp((m+m)*z-kx, 0, 1)),
+		dist(Clamp((-n-m)*z-kx, 0, 1)),
+	))
+}
+
+// Polygon primitive, with the exact distance to the (closed) polygon.
+func (sd SignedDistance) Polygon(v Polygon) float64 {
+	if len(v) == 0 {
+		return math.Inf(1)
+	}
+
+	p := sd.Vec
+	d := p.Sub(v[0]).Dot(p.Sub(v[0]))
+	s := 1.0
+
+	for i, j := 0, len(v)-1; i < len(v); j, i = i, i+1 {
+		e := v[j].Sub(v[i])
+
+		// Repeated points do not form an edge.
+		if e.Dot(e) == 0 {
+			continue
+		}
+
+		w := p.Sub(v[i])
+		b := w.Sub(e.Scaled(Clamp(w.Dot(e)/e.Dot(e), 0, 1)))
+
+		d = MathMin(d, b.Dot(b))
+
+		c1, c2, c3 := p.Y >= v[i].Y, p.Y < v[j].Y, e.X*w.Y > e.Y*w.X
+
+		if (c1 && c2 && c3) || (!c1 && !c2 && !c3) {
+			s = -s
+		}
+	}
+
+	return s * MathSqrt(d)
+}
+
 // Rounded signed distance function shape
 func (sd SignedDistance) Rounded(v, r float64) float64 {
 	return v - r
@@ -152,3 +641,25 @@ func (sd SignedDistance) OpRepeat(c Vec, sdf SignedDistanceFunc) float64 {
 func (sd SignedDistance) OpTx(t Matrix, sdf SignedDistanceFunc) float64 {
 	return sdf(SignedDistance{t.Unproject(sd.Vec)})
 }
+
+// OpElongate elongates the shape by h in each direction.
+func (sd SignedDistance) OpElongate(h Vec, sdf SignedDistanceFunc) float64 {
+	q := sd.Abs().Sub(h)
+
+	return sdf(SignedDistance{q.Max(ZV)}) + MathMin(MathMax(q.X, q.Y), 0)
+}
+
+// OpTranslate moves the shape by the delta vector.
+func (sd SignedDistance) OpTranslate(delta Vec, sdf SignedDistanceFunc) float64 {
+	return sdf(SignedDistance{sd.Sub(delta)})
+}
+
+// OpRotate rotates the shape around the origin by the given angle in radians.
+func (sd SignedDistance) OpRotate(angle float64, sdf SignedDistanceFunc) float64 {
+	return sdf(SignedDistance{sd.Rotated(-angle)})
+}
+
+// OpScale scales the shape around the origin by the scale factor.
+func (sd SignedDistance) OpScale(s float64, sdf SignedDistanceFunc) float64 {
+	return sdf(SignedDistance{sd.Scaled(1 / s)}) * s
+}
@@ -0,0 +1,118 @@
+package gfx
+
+// SignedDistance3 holds 3D signed distance functions based on
+// https://iquilezles.org/www/articles/distfunctions/distfunctions.htm
+//
+// The Y axis is pointing up.
+type SignedDistance3 struct {
+	Vec3
+}
+
+// SignedDistance3Func is a func that takes a SignedDistance3 and returns a float64.
+type SignedDistance3Func func(SignedDistance3) float64
+
+// Sphere primitive
+func (sd SignedDistance3) Sphere(r float64) float64 {
+	return sd.Len() - r
+}
+
+// Box primitive
+func (sd SignedDistance3) Box(b Vec3) float64 {
+	q := sd.Abs().Sub(b)
+
+	return q.Max(ZV3).Len() + MathMin(MathMax(q.X, MathMax(q.Y, q.Z)), 0)
+}
+
+// RoundBox primitive with the corners rounded by r.
+func (sd SignedDistance3) RoundBox(b Vec3, r float64) float64 {
+	q := sd.Abs().Sub(b).AddXYZ(r, r, r)
+
+	return q.Max(ZV3).Len() + MathMin(MathMax(q.X, MathMax(q.Y, q.Z)), 0) - r
+}
+
+// Torus primitive in the XZ plane, with the major radius t.X and minor radius t.Y.
+func (sd SignedDistance3) Torus(t Vec) float64 {
+	q := V(V(sd.X, sd.Z).Len()-t.X, sd.Y)
+
+	return q.Len() - t.Y
+}
+
+// Capsule primitive from a to b with the radius r.
+func (sd SignedDistance3) Capsule(a, b Vec3, r float64) float64 {
+	pa, ba := sd.Sub(a), b.Sub(a)
+
+	h := Clamp(pa.Dot(ba)/ba.Dot(ba), 0, 1)
+
+	return pa.Sub(ba.Scaled(h)).Len() - r
+}
+
+// Cylinder primitive (capped) along the Y axis with the half height h and radius r.
+func (sd SignedDistance3) Cylinder(h, r float64) float64 {
+	d := V(V(sd.X, sd.Z).Len(), sd.Y).Abs().Sub(V(r, h))
+
+	return MathMin(MathMax(d.X, d.Y), 0) + d.Max(ZV).Len()
+}
+
+// Plane primitive with the (unit) normal n, at the distance h from the origin.
+func (sd SignedDistance3) Plane(n Vec3, h float64) float64 {
+	return sd.Dot(n) + h
+}
+
+// OpUnion basic boolean operation for union.
+func (sd SignedDistance3) OpUnion(x, y float64) float64 {
+	return MathMin(x, y)
+}
+
+// OpSubtraction basic boolean operation for subtraction.
+func (sd SignedDistance3) OpSubtraction(x, y float64) float64 {
+	return MathMax(-x, y)
+}
+
+// OpIntersection basic boolean operation for intersection.
+func (sd SignedDistance3) OpIntersection(x, y float64) float64 {
+	return MathMax(x, y)
+}
+
+// OpSmoothUnion smooth operation for union.
+func (sd SignedDistance3) OpSmoothUnion(x, y, k float64) float64 {
+	return SignedDistance{}.OpSmoothUnion(x, y, k)
+}
+
+// OpSmoothSubtraction smooth operation for subtraction.
+func (sd SignedDistance3) OpSmoothSubtraction(x, y, k float64) float64 {
+	return SignedDistance{}.OpSmoothSubtraction(x, y, k)
+}
+
+// OpSmoothIntersection smooth operation for intersection.
+func (sd SignedDistance3) OpSmoothIntersection(x, y, k float64) float64 {
+	return SignedDistance{}.OpSmoothIntersection(x, y, k)
+}
+
+// OpRound rounds the shape by the distance r.
+func (sd SignedDistance3) OpRound(v, r float64) float64 {
+	return v - r
+}
+
+// OpTranslate moves the shape by the delta vector.
+func (sd SignedDistance3) OpTranslate(delta Vec3, sdf SignedDistance3Func) float64 {
+	return sdf(SignedDistance3{sd.Sub(delta)})
+}
+
+// OpScale scales the shape around the origin by the scale factor.
+func (sd SignedDistance3) OpScale(s float64, sdf SignedDistance3Func) float64 {
+	return sdf(SignedDistance3{sd.Scaled(1 / s)}) * s
+}
+
+// OpRepeat repeats the shape in each direction with the period c,
+// where a zero component disables repetition along that axis.
+func (sd SignedDistance3) OpRepeat(c Vec3, sdf SignedDistance3Func) float64 {
+	rep := func(p, c float64) float64 {
+		if c == 0 {
+			return p
+		}
+
+		return p - c*MathFloor(p/c+0.5)
+	}
+
+	return sdf(SignedDistance3{V3(rep(sd.X, c.X), rep(sd.Y, c.Y), rep(sd.Z, c.Z))})
+}
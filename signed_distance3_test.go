@@ -0,0 +1,47 @@
+package gfx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSignedDistance3(t *testing.T) {
+	sd := SignedDistance3{V3(0, 3, 0)}
+
+	for _, tc := range []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"Sphere", sd.Sphere(1), 2},
+		{"Box", sd.Box(V3(1, 1, 1)), 2},
+		{"Box corner", SignedDistance3{V3(2, 2, 2)}.Box(V3(1, 1, 1)), math.Sqrt(3)},
+		{"Box inside", SignedDistance3{}.Box(V3(1, 2, 3)), -1},
+		{"RoundBox", SignedDistance3{V3(2, 2, 2)}.RoundBox(V3(1, 1, 1), 0.5), math.Sqrt(3)*1.5 - 0.5},
+		{"Torus", sd.Torus(V(4, 1)), 4},
+		{"Torus tube", SignedDistance3{V3(0, 0, 4)}.Torus(V(4, 1)), -1},
+		{"Capsule", sd.Capsule(V3(-2, 0, 0), V3(2, 0, 0), 1), 2},
+		{"Cylinder", sd.Cylinder(1, 2), 2},
+		{"Cylinder side", SignedDistance3{V3(0, 0, 5)}.Cylinder(1, 2), 3},
+		{"Plane", sd.Plane(V3(0, 1, 0), 1), 4},
+		{"OpUnion", sd.OpUnion(1, 2), 1},
+		{"OpSubtraction", sd.OpSubtraction(1, 2), 2},
+		{"OpIntersection", sd.OpIntersection(1, 2), 2},
+		{"OpSmoothUnion", sd.OpSmoothUnion(1, 1, 1), 0.75},
+		{"OpTranslate", sd.OpTranslate(V3(0, 5, 0), func(sd SignedDistance3) float64 {
+			return sd.Sphere(1)
+		}), 1},
+		{"OpScale", sd.OpScale(2, func(sd SignedDistance3) float64 {
+			return sd.Sphere(1)
+		}), 1},
+		{"OpRepeat", SignedDistance3{V3(9, 0, 0)}.OpRepeat(V3(10, 0, 0), func(sd SignedDistance3) float64 {
+			return sd.Sphere(2)
+		}), -1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if MathAbs(tc.got-tc.want) > 1e-9 {
+				t.Fatalf("d = %v, want %v", tc.got, tc.want)
+			}
+		})
+	}
+}
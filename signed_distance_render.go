@@ -0,0 +1,214 @@
+package gfx
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// SignedDistanceGlow is a glow that fades out over the Radius.
+type SignedDistanceGlow struct {
+	Color  color.Color
+	Radius float64
+}
+
+// SignedDistanceShadow is a shadow offset from the shape, with a blur radius.
+type SignedDistanceShadow struct {
+	Color  color.Color
+	Offset Vec
+	Blur   float64
+}
+
+// SignedDistanceRenderer renders a SignedDistanceFunc onto images, using
+// the distance and the size of a pixel to compute anti-aliased coverage.
+//
+// Layers with a nil color are not drawn. From bottom to top the layers are:
+// Shadow, OuterGlow, Color (the fill), InnerGlow, Outline and Stroke.
+//
+// The Matrix transforms the signed distance function into image space,
+// where the zero value Matrix is treated as IM.
+//
+// Samples is the number of samples per axis used for supersampling, and
+// Parallel renders rows in parallel. (Only use Parallel when concurrent
+// calls to Set on different pixels of the destination image are safe)
+type SignedDistanceRenderer struct {
+	Color        color.Color
+	StrokeColor  color.Color
+	StrokeWidth  float64
+	OutlineColor color.Color
+	OutlineWidth float64
+	InnerGlow    SignedDistanceGlow
+	OuterGlow    SignedDistanceGlow
+	Shadow       SignedDistanceShadow
+	Matrix       Matrix
+	Samples      int
+	Parallel     bool
+}
+
+// signedDistanceLayers is the coverage of each layer of a SignedDistanceRenderer.
+type signedDistanceLayers [6]float64
+
+// Draw the signed distance function onto dst using Mix.
+func (sdr SignedDistanceRenderer) Draw(dst draw.Image, sdf SignedDistanceFunc) {
+	sdr.DrawRect(dst, dst.Bounds(), sdf)
+}
+
+// DrawRect draws the signed distance function onto the part of dst within r using Mix.
+func (sdr SignedDistanceRenderer) DrawRect(dst draw.Image, r image.Rectangle, sdf SignedDistanceFunc) {
+	r = r.Intersect(dst.Bounds())
+
+	if r.Empty() {
+		return
+	}
+
+	m := sdr.Matrix
+
+	if m == (Matrix{}) {
+		m = IM
+	}
+
+	det := math.Abs(m[0]*m[3] - m[2]*m[1])
+
+	if det == 0 {
+		return
+	}
+
+	n := IntMax(1, sdr.Samples)
+
+	// The size of a (sub)pixel in the space of the signed distance function.
+	w := 1 / math.Sqrt(det) / float64(n)
+
+	colors := [6]color.Color{
+		sdr.Shadow.Color,
+		sdr.OuterGlow.Color,
+		sdr.Color,
+		sdr.InnerGlow.Color,
+		sdr.OutlineColor,
+		sdr.StrokeColor,
+	}
+
+	row := func(y int) {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			var sum signedDistanceLayers
+
+			for sy := 0; sy < n; sy++ {
+				for sx := 0; sx < n; sx++ {
+					u := m.Unproject(V(
+						float64(x)+(float64(sx)+0.5)/float64(n),
+						float64(y)+(float64(sy)+0.5)/float64(n),
+					))
+
+					l := sdr.layers(sdf, u, w)
+
+					for i := range sum {
+						sum[i] += l[i]
+					}
+				}
+			}
+
+			for i, c := range colors {
+				if c == nil {
+					continue
+				}
+
+				if t := sum[i] / float64(n*n); t > 0 {
+					Mix(dst, x, y, colorWithCoverage(c, t))
+				}
+			}
+		}
+	}
+
+	drawRows(r, sdr.Parallel, row)
+}
+
+// layers returns the coverage of each layer at u, where w is the size of a pixel.
+func (sdr SignedDistanceRenderer) layers(sdf SignedDistanceFunc, u Vec, w float64) signedDistanceLayers {
+	var l signedDistanceLayers
+
+	d := sdf(SignedDistance{u})
+
+	fill := signedDistanceCoverage(d, w)
+
+	if sdr.Shadow.Color != nil {
+		sd := sdf(SignedDistance{u.Sub(sdr.Shadow.Offset)})
+
+		l[0] = signedDistanceCoverage(sd, math.Max(w, sdr.Shadow.Blur))
+	}
+
+	if sdr.OuterGlow.Color != nil && sdr.OuterGlow.Radius > 0 && d > 0 {
+		g := 1 - d/sdr.OuterGlow.Radius
+
+		l[1] = Clamp(g, 0, 1) * Clamp(g, 0, 1) * (1 - fill)
+	}
+
+	l[2] = fill
+
+	if sdr.InnerGlow.Color != nil && sdr.InnerGlow.Radius > 0 && d < 0 {
+		g := 1 + d/sdr.InnerGlow.Radius
+
+		l[3] = Clamp(g, 0, 1) * Clamp(g, 0, 1) * fill
+	}
+
+	if sdr.OutlineWidth > 0 {
+		l[4] = math.Max(0, signedDistanceCoverage(d-sdr.OutlineWidth, w)-fill)
+	}
+
+	if sdr.StrokeWidth > 0 {
+		l[5] = signedDistanceCoverage(math.Abs(d)-sdr.StrokeWidth/2, w)
+	}
+
+	return l
+}
+
+// signedDistanceCoverage returns the coverage (range 0-1) of a pixel of size w
+// at the signed distance d, which is 0.5 on the boundary of the shape.
+func signedDistanceCoverage(d, w float64) float64 {
+	return Clamp(0.5-d/w, 0, 1)
+}
+
+// colorWithCoverage returns the color with its alpha multiplied by the coverage t.
+func colorWithCoverage(c color.Color, t float64) color.Color {
+	n := color.NRGBA64Model.Convert(c).(color.NRGBA64)
+
+	n.A = uint16(math.Round(float64(n.A) * Clamp(t, 0, 1)))
+
+	return n
+}
+
+// drawRows calls row for each row of r, in parallel if requested.
+func drawRows(r image.Rectangle, parallel bool, row func(y int)) {
+	if !parallel {
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			row(y)
+		}
+
+		return
+	}
+
+	rows := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for y := range rows {
+				row(y)
+			}
+		}()
+	}
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		rows <- y
+	}
+
+	close(rows)
+
+	wg.Wait()
+}
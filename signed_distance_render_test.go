@@ -0,0 +1,97 @@
+package gfx
+
+import (
+	"image"
+	"testing"
+)
+
+func TestSignedDistanceRenderer(t *testing.T) {
+	circle := func(sd SignedDistance) float64 {
+		return sd.Sub(V(10, 10)).Len() - 5
+	}
+
+	draw := func(sdr SignedDistanceRenderer) *image.NRGBA {
+		m := NewNRGBA(IR(0, 0, 20, 20))
+
+		sdr.Draw(m, circle)
+
+		return m
+	}
+
+	t.Run("Fill", func(t *testing.T) {
+		m := draw(SignedDistanceRenderer{Color: ColorRed})
+
+		if got, want := m.NRGBAAt(10, 10), ColorRed; got != want {
+			t.Fatalf("m.NRGBAAt(10, 10) = %v, want %v", got, want)
+		}
+
+		if got, want := m.NRGBAAt(0, 0), ColorTransparent; got != want {
+			t.Fatalf("m.NRGBAAt(0, 0) = %v, want %v", got, want)
+		}
+
+		// The pixel center (14.5, 12.5) is very close to the edge.
+		if got := m.NRGBAAt(14, 12).A; got < 64 || got > 192 {
+			t.Fatalf("m.NRGBAAt(14, 12).A = %d, want partial coverage", got)
+		}
+	})
+
+	t.Run("Layers", func(t *testing.T) {
+		m := draw(SignedDistanceRenderer{
+			StrokeColor:  ColorGreen,
+			StrokeWidth:  1,
+			OutlineColor: ColorBlue,
+			OutlineWidth: 3,
+			Shadow:       SignedDistanceShadow{Color: ColorBlack, Offset: V(0, 8)},
+		})
+
+		if got := m.NRGBAAt(10, 10); got.A != 0 {
+			t.Fatalf("m.NRGBAAt(10, 10) = %v, want transparent", got)
+		}
+
+		if got, want := m.NRGBAAt(16, 10), ColorBlue; got != want {
+			t.Fatalf("m.NRGBAAt(16, 10) = %v, want %v", got, want)
+		}
+
+		if got, want := m.NRGBAAt(10, 19), ColorBlack; got != want {
+			t.Fatalf("m.NRGBAAt(10, 19) = %v, want %v", got, want)
+		}
+
+		if got := m.NRGBAAt(10, 5); got.G == 0 {
+			t.Fatalf("m.NRGBAAt(10, 5) = %v, want stroke", got)
+		}
+	})
+
+	t.Run("Glow", func(t *testing.T) {
+		m := draw(SignedDistanceRenderer{
+			OuterGlow: SignedDistanceGlow{ColorWhite, 4},
+			InnerGlow: SignedDistanceGlow{ColorRed, 2},
+		})
+
+		if got := m.NRGBAAt(16, 10).A; got == 0 || got == 255 {
+			t.Fatalf("m.NRGBAAt(16, 10).A = %d, want partial glow", got)
+		}
+
+		if got := m.NRGBAAt(10, 10).A; got != 0 {
+			t.Fatalf("m.NRGBAAt(10, 10).A = %d, want 0", got)
+		}
+	})
+
+	t.Run("ParallelSupersampling", func(t *testing.T) {
+		want := draw(SignedDistanceRenderer{Color: ColorRed, Samples: 4})
+		got := draw(SignedDistanceRenderer{Color: ColorRed, Samples: 4, Parallel: true})
+
+		for i := range want.Pix {
+			if got.Pix[i] != want.Pix[i] {
+				t.Fatalf("got.Pix[%d] = %d, want %d", i, got.Pix[i], want.Pix[i])
+			}
+		}
+	})
+
+	t.Run("Matrix", func(t *testing.T) {
+		m := draw(SignedDistanceRenderer{Color: ColorRed, Matrix: IM.Moved(V(-8, 0))})
+
+		if got, want := m.NRGBAAt(2, 10), ColorRed; got != want {
+			t.Fatalf("m.NRGBAAt(2, 10) = %v, want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package gfx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSignedDistancePrimitives(t *testing.T) {
+	hollow := map[string]bool{"Arc": true, "Ring": true, "Horseshoe": true, "QuadraticBezier": true}
+
+	for _, tc := range []struct {
+		name string
+		sdf  SignedDistanceFunc
+	}{
+		{"RoundedBox", func(sd SignedDistance) float64 {
+			return sd.RoundedBox(V(10, 6), [4]float64{1, 2, 3, 4})
+		}},
+		{"OrientedBox", func(sd SignedDistance) float64 { return sd.OrientedBox(V(-8, -4), V(8, 6), 5) }},
+		{"Trapezoid", func(sd SignedDistance) float64 { return sd.Trapezoid(10, 5, 6) }},
+		{"Parallelogram", func(sd SignedDistance) float64 { return sd.Parallelogram(8, 5, 3) }},
+		{"Triangle", func(sd SignedDistance) float64 { return sd.Triangle(V(-9, 7), V(0, -10), V(10, 5)) }},
+		{"Pentagon", func(sd SignedDistance) float64 { return sd.Pentagon(8) }},
+		{"Hexagon", func(sd SignedDistance) float64 { return sd.Hexagon(8) }},
+		{"Octagon", func(sd SignedDistance) float64 { return sd.Octagon(8) }},
+		{"Star", func(sd SignedDistance) float64 { return sd.Star(10, 5, 3) }},
+		{"Pie", func(sd SignedDistance) float64 { return sd.Pie(2, 10) }},
+		{"Arc", func(sd SignedDistance) float64 { return sd.Arc(2, 8, 2) }},
+		{"Ring", func(sd SignedDistance) float64 { return sd.Ring(2, 8, 3) }},
+		{"Horseshoe", func(sd SignedDistance) float64 { return sd.Horseshoe(1, 7, V(4, 2)) }},
+		{"Vesica", func(sd SignedDistance) float64 { return sd.Vesica(10, 6) }},
+		{"Egg", func(sd SignedDistance) float64 { return sd.Egg(8, 3) }},
+		{"Heart", func(sd SignedDistance) float64 {
+			return sd.OpScale(12, func(sd SignedDistance) float64 { return sd.Heart() })
+		}},
+		{"Cross", func(sd SignedDistance) float64 { return sd.Cross(V(10, 3), 1) }},
+		{"Ellipse", func(sd SignedDistance) float64 { return sd.Ellipse(V(10, 5)) }},
+		{"Parabola", func(sd SignedDistance) float64 { return sd.Parabola(0.2) }},
+		{"QuadraticBezier", func(sd SignedDistance) float64 { return sd.QuadraticBezier(V(-10, 5), V(0, -15), V(10, 5)) }},
+		{"Polygon", func(sd SignedDistance) float64 {
+			return sd.Polygon(Polygon{{-10, -10}, {10, -10}, {0, 0}, {10, 10}, {-10, 10}})
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := tc.sdf(SignedDistance{V(0, 1)}); d >= 0 && !hollow[tc.name] {
+				t.Fatalf("d = %v, want negative inside", d)
+			}
+
+			if d := tc.sdf(SignedDistance{V(40, 40)}); d <= 0 {
+				t.Fatalf("d = %v, want positive outside", d)
+			}
+
+			// The distance field may not change faster than the distance moved.
+			for y := -20.0; y <= 20; y += 0.75 {
+				for x := -20.0; x <= 20; x += 0.75 {
+					p := V(x, y)
+					d := tc.sdf(SignedDistance{p})
+
+					for _, o := range []Vec{{0.25, 0}, {0, 0.25}, {0.25, 0.25}} {
+						e := tc.sdf(SignedDistance{p.Add(o)})
+
+						if math.IsNaN(d) || MathAbs(e-d) > o.Len()*1.001+1e-6 {
+							t.Fatalf("%v: d = %v, %v: d = %v", p, d, p.Add(o), e)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSignedDistanceValues(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"RoundedBox", SignedDistance{V(12, 0)}.RoundedBox(V(10, 5), [4]float64{}), 2},
+		{"RoundedBox corner", SignedDistance{V(11, 6)}.RoundedBox(V(10, 5), [4]float64{2, 0, 0, 0}), math.Sqrt(18) - 2},
+		{"OrientedBox", SignedDistance{V(0, 4)}.OrientedBox(V(-5, 0), V(5, 0), 2), 3},
+		{"Triangle", SignedDistance{V(0, -1)}.Triangle(V(0, 0), V(4, 0), V(0, 4)), 1},
+		{"Triangle inside", SignedDistance{V(1, 1)}.Triangle(V(0, 0), V(4, 0), V(0, 4)), -1},
+		{"Triangle repeated vertex", SignedDistance{V(4, 0)}.Triangle(V(0, 0), V(0, 0), V(4, 4)), 2 * math.Sqrt2},
+		{"Triangle collinear", SignedDistance{V(8, 8)}.Triangle(V(0, 0), V(2, 2), V(4, 4)), 4 * math.Sqrt2},
+		{"Hexagon", SignedDistance{V(0, 12)}.Hexagon(8), 4},
+		{"Octagon", SignedDistance{V(12, 0)}.Octagon(8), 4},
+		{"Pentagon", SignedDistance{V(0, 0)}.Pentagon(8), -8},
+		{"Ellipse", SignedDistance{V(0, 8)}.Ellipse(V(10, 5)), 3},
+		{"Ellipse X", SignedDistance{V(-14, 0)}.Ellipse(V(10, 5)), 4},
+		{"Ellipse circle", SignedDistance{V(0, 8)}.Ellipse(V(5, 5)), 3},
+		{"Parabola", SignedDistance{V(0, -3)}.Parabola(1), 3},
+		{"Vesica", SignedDistance{V(0, 0)}.Vesica(10, 6), -4},
+		{"Cross", SignedDistance{V(0, 12)}.Cross(V(10, 3), 0), 2},
+		{"Cross inside", SignedDistance{V(0, 5)}.Cross(V(10, 3), 0), -3},
+		{"Egg", SignedDistance{V(0, -10)}.Egg(8, 3), 2},
+		{"Pie", SignedDistance{V(0, 12)}.Pie(1, 10), 2},
+		{"Arc", SignedDistance{V(0, 12)}.Arc(1, 10, 1), 1},
+		{"QuadraticBezier", SignedDistance{V(0, 3)}.QuadraticBezier(V(-5, 0), V(0, 0), V(5, 0)), 3},
+		{"QuadraticBezier curve", SignedDistance{V(1, 3)}.QuadraticBezier(V(-5, 10), V(0, -10), V(5, 10)),
+			-SignedDistance{V(1, 3)}.Parabola(0.4)},
+		{"Polygon", SignedDistance{V(0, 0)}.Polygon(Polygon{{-2, -2}, {2, -2}, {2, 2}, {-2, 2}}), -2},
+		{"Polygon outside", SignedDistance{V(5, 6)}.Polygon(Polygon{{-2, -2}, {2, -2}, {2, 2}, {-2, 2}}), 5},
+		{"Polygon closed", SignedDistance{V(0, 0)}.Polygon(Polygon{{-2, -2}, {2, -2}, {2, 2}, {-2, 2}, {-2, -2}}), -2},
+		{"Polygon repeated vertex", SignedDistance{V(5, 6)}.Polygon(Polygon{{-2, -2}, {2, -2}, {2, -2}, {2, 2}, {-2, 2}}), 5},
+		{"OrientedBox degenerate", SignedDistance{V(4, 0)}.OrientedBox(V(1, 0), V(1, 0), 2), 3},
+		{"OpElongate", SignedDistance{V(12, 0)}.OpElongate(V(5, 0), func(sd SignedDistance) float64 {
+			return sd.Circle(4)
+		}), 3},
+		{"OpTranslate", SignedDistance{V(10, 10)}.OpTranslate(V(10, 5), func(sd SignedDistance) float64 {
+			return sd.Circle(2)
+		}), 3},
+		{"OpRotate", SignedDistance{V(0, 9)}.OpRotate(math.Pi/2, func(sd SignedDistance) float64 {
+			return sd.Rectangle(V(10, 2))
+		}), -1},
+		{"OpScale", SignedDistance{V(10, 0)}.OpScale(2, func(sd SignedDistance) float64 {
+			return sd.Circle(2)
+		}), 6},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if !(MathAbs(tc.got-tc.want) <= 1e-6) {
+				t.Fatalf("d = %v, want %v", tc.got, tc.want)
+			}
+		})
+	}
+}
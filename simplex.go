@@ -26,10 +26,22 @@ type SimplexNoise struct {
 
 // NewSimplexNoise creates a new simplex noise instance with the given seed
 func NewSimplexNoise(seed int64) *SimplexNoise {
+	perm := noisePermutation(seed)
+	permMod12 := make([]uint8, 512)
+
+	for i := 0; i < 512; i++ {
+		permMod12[i] = perm[i] % 12
+	}
+
+	return &SimplexNoise{perm, permMod12}
+}
+
+// noisePermutation returns a permutation table of 256 values shuffled using the
+// seed. The table is doubled in length to remove the need for index wrapping.
+func noisePermutation(seed int64) []uint8 {
 	var p [256]uint8
 
 	perm := make([]uint8, 512)
-	permMod12 := make([]uint8, 512)
 
 	for i := 0; i < 256; i++ {
 		p[i] = uint8(i)
@@ -42,14 +54,11 @@ func NewSimplexNoise(seed int64) *SimplexNoise {
 		p[i], p[si] = p[si], p[i]
 	}
 
-	// To remove the need for index wrapping, double the permutation table length
-
 	for i := 0; i < 512; i++ {
 		perm[i] = p[i&255]
-		permMod12[i] = perm[i] % 12
 	}
 
-	return &SimplexNoise{perm, permMod12}
+	return perm
 }
 
 // Noise2D performs 2D simplex noise
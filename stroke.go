@@ -0,0 +1,332 @@
+package gfx
+
+import (
+	"math"
+)
+
+// LineJoin specifies the shape of the joint between two line segments.
+type LineJoin int
+
+const (
+	// MiterLineJoin extends the outer edges of the segments until they meet.
+	MiterLineJoin LineJoin = iota
+
+	// RoundLineJoin is a circular joint.
+	RoundLineJoin
+
+	// BevelLineJoin cuts off the corner between the outer edges of the segments.
+	BevelLineJoin
+)
+
+// DefaultMiterLimit is the miter limit used when Stroke.MiterLimit is zero.
+const DefaultMiterLimit = 4
+
+// Stroke turns polygons and paths into outlines of the given width.
+//
+// The outline is returned as a Polyline of convex polygons that all have
+// the same orientation, meaning that it can be filled using NonZeroFillRule
+// or turned into TrianglesData.
+type Stroke struct {
+	// Width of the stroke.
+	Width float64
+
+	// Join is the shape of the joints between segments.
+	Join LineJoin
+
+	// Cap is the shape of the ends of open lines. NoEndShape gives butt caps.
+	Cap EndShape
+
+	// MiterLimit is the maximum ratio between the miter length and half the
+	// width before a miter joint is turned into a bevel joint.
+	MiterLimit float64
+
+	// Dashes are the alternating lengths of dashes and gaps.
+	// An odd number of values is repeated to yield an even number.
+	Dashes []float64
+
+	// DashOffset is the distance into the dash pattern to start at.
+	DashOffset float64
+}
+
+// Outline returns the stroke outline of the polygon.
+// The polygon is treated as a closed shape if closed is true.
+func (s Stroke) Outline(p Polygon, closed bool) Polyline {
+	if s.Width <= 0 {
+		return nil
+	}
+
+	var pl Polyline
+
+	pts := dedupPolygon(p, closed)
+
+	if len(s.Dashes) > 0 {
+		for _, d := range s.dash(pts, closed) {
+			pl = append(pl, s.outline(d, false)...)
+		}
+
+		return pl
+	}
+
+	return s.outline(pts, closed)
+}
+
+// PathOutline returns the stroke outline of all contours in the path.
+func (s Stroke) PathOutline(p *Path) Polyline {
+	var pl Polyline
+
+	for _, c := range p.contours(PathTolerance) {
+		pl = append(pl, s.Outline(c.pts, c.closed)...)
+	}
+
+	return pl
+}
+
+func (s Stroke) outline(pts Polygon, closed bool) Polyline {
+	n := len(pts)
+
+	if n < 2 || (closed && n < 3) {
+		return nil
+	}
+
+	var pl Polyline
+
+	add := func(p Polygon) {
+		if area := polygonArea(p); area < 0 {
+			for i, j := 0, len(p)-1; i < j; i, j = i+1, j-1 {
+				p[i], p[j] = p[j], p[i]
+			}
+		} else if area == 0 {
+			return
+		}
+
+		pl = append(pl, p)
+	}
+
+	hw := s.Width / 2
+
+	segments := n - 1
+	if closed {
+		segments = n
+	}
+
+	for i := 0; i < segments; i++ {
+		a, b := pts[i], pts[(i+1)%n]
+		nv := a.To(b).Unit().Normal().Scaled(hw)
+
+		add(Polygon{a.Add(nv), b.Add(nv), b.Sub(nv), a.Sub(nv)})
+	}
+
+	for i := 0; i < n; i++ {
+		if !closed && (i == 0 || i == n-1) {
+			continue
+		}
+
+		prev, next := pts[(i+n-1)%n], pts[(i+1)%n]
+
+		if j := s.join(prev, pts[i], next, hw); j != nil {
+			add(j)
+		}
+	}
+
+	if !closed {
+		if c := s.cap(pts[0], pts[0].To(pts[1]).Unit().Scaled(-1), hw); c != nil {
+			add(c)
+		}
+
+		if c := s.cap(pts[n-1], pts[n-2].To(pts[n-1]).Unit(), hw); c != nil {
+			add(c)
+		}
+	}
+
+	return pl
+}
+
+// join returns the joint polygon at the pivot between the two segments.
+func (s Stroke) join(prev, pivot, next Vec, hw float64) Polygon {
+	d0, d1 := prev.To(pivot).Unit(), pivot.To(next).Unit()
+
+	cross := d0.Cross(d1)
+
+	if math.Abs(cross) < 1e-12 {
+		return nil
+	}
+
+	sign := -Sign(cross)
+
+	n0, n1 := d0.Normal().Scaled(sign), d1.Normal().Scaled(sign)
+
+	switch s.Join {
+	case RoundLineJoin:
+		return append(Polygon{pivot}, arcPoints(pivot, n0.Scaled(hw), n1.Angle()-n0.Angle(), hw)...)
+	case MiterLineJoin:
+		limit := s.MiterLimit
+		if limit == 0 {
+			limit = DefaultMiterLimit
+		}
+
+		m := n0.Add(n1).Unit()
+
+		if cos := m.Dot(n0); cos > 0 && 1/cos <= limit {
+			return Polygon{pivot, pivot.Add(n0.Scaled(hw)), pivot.Add(m.Scaled(hw / cos)), pivot.Add(n1.Scaled(hw))}
+		}
+	}
+
+	return Polygon{pivot, pivot.Add(n0.Scaled(hw)), pivot.Add(n1.Scaled(hw))}
+}
+
+// cap returns the end cap polygon at the end point u, facing in direction d.
+func (s Stroke) cap(u, d Vec, hw float64) Polygon {
+	nv := d.Normal().Scaled(hw)
+	dv := d.Scaled(hw)
+
+	switch s.Cap {
+	case SharpEndShape:
+		return Polygon{u.Add(nv), u.Add(dv), u.Sub(nv)}
+	case RoundEndShape:
+		return append(Polygon{u}, arcPoints(u, nv, -math.Pi, hw)...)
+	case SquareEndShape:
+		return Polygon{u.Add(nv), u.Add(nv).Add(dv), u.Sub(nv).Add(dv), u.Sub(nv)}
+	}
+
+	return nil
+}
+
+// dash splits the polygon into open polygons based on the dash pattern.
+func (s Stroke) dash(pts Polygon, closed bool) []Polygon {
+	pattern := s.Dashes
+
+	if len(pattern)%2 == 1 {
+		pattern = append(append([]float64{}, pattern...), pattern...)
+	}
+
+	var total float64
+
+	for _, l := range pattern {
+		if l < 0 {
+			return []Polygon{pts}
+		}
+
+		total += l
+	}
+
+	if total <= 0 || len(pts) < 2 {
+		return []Polygon{pts}
+	}
+
+	if closed {
+		pts = append(append(Polygon{}, pts...), pts[0])
+	}
+
+	i, off := 0, math.Mod(s.DashOffset, total)
+
+	if off < 0 {
+		off += total
+	}
+
+	for off >= pattern[i] {
+		off -= pattern[i]
+		i = (i + 1) % len(pattern)
+	}
+
+	var (
+		dashes []Polygon
+		cur    Polygon
+		rem    = pattern[i] - off
+		on     = i%2 == 0
+	)
+
+	if on {
+		cur = Polygon{pts[0]}
+	}
+
+	for j := 1; j < len(pts); j++ {
+		a, b := pts[j-1], pts[j]
+
+		l, pos := a.To(b).Len(), 0.0
+
+		for l-pos > rem {
+			pos += rem
+
+			u := a.Lerp(b, pos/l)
+
+			if on {
+				dashes = append(dashes, append(cur, u))
+				cur = nil
+			} else {
+				cur = Polygon{u}
+			}
+
+			on = !on
+			i = (i + 1) % len(pattern)
+			rem = pattern[i]
+		}
+
+		rem -= l - pos
+
+		if on {
+			cur = append(cur, b)
+		}
+	}
+
+	if on && len(cur) > 1 {
+		dashes = append(dashes, cur)
+	}
+
+	return dashes
+}
+
+// arcPoints returns the points along a circular arc around the center,
+// starting at center+from and turning the given angle.
+func arcPoints(center, from Vec, angle, r float64) Polygon {
+	for angle > math.Pi {
+		angle -= 2 * math.Pi
+	}
+
+	for angle < -math.Pi {
+		angle += 2 * math.Pi
+	}
+
+	step := math.Pi / 2
+
+	if r > PathTolerance {
+		step = 2 * math.Acos(1-PathTolerance/r)
+	}
+
+	n := IntMax(1, int(math.Ceil(math.Abs(angle)/step)))
+
+	pts := make(Polygon, n+1)
+
+	for i := 0; i <= n; i++ {
+		pts[i] = center.Add(from.Rotated(angle * float64(i) / float64(n)))
+	}
+
+	return pts
+}
+
+// dedupPolygon returns the polygon without consecutive duplicate points.
+func dedupPolygon(p Polygon, closed bool) Polygon {
+	var pts Polygon
+
+	for _, u := range p {
+		if len(pts) == 0 || pts[len(pts)-1] != u {
+			pts = append(pts, u)
+		}
+	}
+
+	if closed && len(pts) > 1 && pts[0] == pts[len(pts)-1] {
+		pts = pts[:len(pts)-1]
+	}
+
+	return pts
+}
+
+// polygonArea returns the signed area of the polygon.
+func polygonArea(p Polygon) float64 {
+	var a float64
+
+	for i := range p {
+		a += p[i].Cross(p[(i+1)%len(p)])
+	}
+
+	return a / 2
+}
@@ -0,0 +1,118 @@
+package gfx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStrokeOutline(t *testing.T) {
+	p := Polygon{{2, 2}, {10, 2}, {10, 10}}
+
+	for _, tc := range []struct {
+		s    Stroke
+		u    Vec
+		want bool
+	}{
+		{Stroke{Width: 2}, V(10.9, 1.1), true},
+		{Stroke{Width: 2, Join: BevelLineJoin}, V(10.9, 1.1), false},
+		{Stroke{Width: 2, Join: RoundLineJoin}, V(10.6, 1.4), true},
+		{Stroke{Width: 2, Join: RoundLineJoin}, V(10.9, 1.1), false},
+		{Stroke{Width: 2}, V(1.5, 2), false},
+		{Stroke{Width: 2, Cap: SquareEndShape}, V(1.5, 2), true},
+		{Stroke{Width: 2, Cap: RoundEndShape}, V(1.5, 2.5), true},
+		{Stroke{Width: 2, Cap: RoundEndShape}, V(1.2, 2.9), false},
+		{Stroke{Width: 2}, V(6, 6), false},
+	} {
+		pl := tc.s.Outline(p, false)
+
+		if got := NonZeroFillRule.Inside(pl.Winding(tc.u)); got != tc.want {
+			t.Fatalf("%+v contains %v = %v, want %v", tc.s, tc.u, got, tc.want)
+		}
+	}
+}
+
+func TestStrokeMiterLimit(t *testing.T) {
+	p := Polygon{{0, 0}, {10, 0}, {0, 1}}
+
+	pl := Stroke{Width: 2}.Outline(p, false)
+
+	if got := pl.Rect().Max.X; got > 12 {
+		t.Fatalf("pl.Rect().Max.X = %v, expected miter to be beveled", got)
+	}
+
+	pl = Stroke{Width: 2, MiterLimit: 100}.Outline(p, false)
+
+	if got := pl.Rect().Max.X; got < 20 {
+		t.Fatalf("pl.Rect().Max.X = %v, expected miter to extend", got)
+	}
+}
+
+func TestStrokeOrientation(t *testing.T) {
+	p := Polygon{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+
+	for _, pg := range (Stroke{Width: 3, Join: RoundLineJoin}).Outline(p, true) {
+		if polygonArea(pg) <= 0 {
+			t.Fatalf("unexpected orientation of %v", pg)
+		}
+	}
+}
+
+func TestStrokeDashes(t *testing.T) {
+	p := Polygon{{0, 0}, {10, 0}}
+
+	for _, tc := range []struct {
+		dashes []float64
+		offset float64
+		want   []float64
+	}{
+		{[]float64{2, 1}, 0, []float64{0, 2, 3, 5, 6, 8, 9, 10}},
+		{[]float64{2, 1}, 1, []float64{0, 1, 2, 4, 5, 7, 8, 10}},
+		{[]float64{3}, 0, []float64{0, 3, 6, 9}},
+	} {
+		dashes := Stroke{Width: 1, Dashes: tc.dashes, DashOffset: tc.offset}.dash(p, false)
+
+		var got []float64
+
+		for _, d := range dashes {
+			got = append(got, d[0].X, d[len(d)-1].X)
+		}
+
+		if len(got) != len(tc.want) {
+			t.Fatalf("dash ends = %v, want %v", got, tc.want)
+		}
+
+		for i := range got {
+			if math.Abs(got[i]-tc.want[i]) > 1e-9 {
+				t.Fatalf("dash ends = %v, want %v", got, tc.want)
+			}
+		}
+	}
+}
+
+func TestStrokePathOutline(t *testing.T) {
+	var p Path
+
+	p.MoveTo(V(4, 4))
+	p.CubicTo(V(10, 0), V(20, 30), V(28, 20))
+
+	dst := NewImage(32, 32)
+
+	if got := p.Stroke(dst, ColorRed, Stroke{Width: 3, Cap: RoundEndShape}); got == 0 {
+		t.Fatalf("expected pixels to be drawn")
+	}
+
+	td := Stroke{Width: 2, Join: RoundLineJoin}.PathOutline(&p).TrianglesData(ColorRed)
+
+	if td.Len() == 0 || td.Len()%3 != 0 {
+		t.Fatalf("unexpected td.Len() = %d", td.Len())
+	}
+}
+
+func TestIMDrawSquareEndShape(t *testing.T) {
+	imd := NewIMDraw(nil)
+
+	imd.EndShape = SquareEndShape
+
+	imd.Push(V(1, 1), V(10, 10))
+	imd.Line(2)
+}
@@ -0,0 +1,125 @@
+package gfx
+
+import (
+	"image"
+	"image/draw"
+	"math"
+)
+
+// DrawTransformed draws the src image onto dst, transformed by the matrix m,
+// using source-over compositing.
+//
+// Every destination pixel is mapped back into the src image using
+// Matrix.Unproject and sampled using the filter.
+// (BoxResampleFilter samples the nearest pixel, BilinearResampleFilter
+// and CatmullRomResampleFilter are bilinear and bicubic respectively)
+//
+// The filter is not widened when the image is scaled down.
+func DrawTransformed(dst draw.Image, src image.Image, m Matrix, filter ResampleFilter) {
+	sb := src.Bounds()
+
+	if sb.Empty() || m[0]*m[3]-m[2]*m[1] == 0 {
+		return
+	}
+
+	// The area of dst covered by the transformed src bounds.
+	var r Rect
+
+	for i, u := range []Vec{
+		IV(sb.Min.X, sb.Min.Y),
+		IV(sb.Max.X, sb.Min.Y),
+		IV(sb.Min.X, sb.Max.Y),
+		IV(sb.Max.X, sb.Max.Y),
+	} {
+		p := m.Project(u)
+
+		if i == 0 {
+			r = Rect{p, p}
+		} else {
+			r = r.Union(Rect{p, p})
+		}
+	}
+
+	// Expand the area by the filter support, scaled by the matrix.
+	e := V(filter.Support*math.Hypot(m[0], m[2]), filter.Support*math.Hypot(m[1], m[3]))
+
+	db := rasterBounds(Rect{r.Min.Sub(e), r.Max.Add(e)}, dst)
+
+	if db.Empty() {
+		return
+	}
+
+	ib := newImageBuffer(src)
+
+	// Convert the src pixels into premultiplied linear light.
+	for i, p := range ib.pix {
+		if a := p[3]; a > 0 {
+			ib.pix[i] = [4]float64{
+				sRGBToLinear(p[0]/a) * a,
+				sRGBToLinear(p[1]/a) * a,
+				sRGBToLinear(p[2]/a) * a,
+				a,
+			}
+		}
+	}
+
+	support := filter.Support
+
+	for y := db.Min.Y; y < db.Max.Y; y++ {
+		for x := db.Min.X; x < db.Max.X; x++ {
+			s := m.Unproject(IV(x, y).AddXY(0.5, 0.5)).Sub(IV(sb.Min.X, sb.Min.Y))
+
+			x0, x1 := int(math.Floor(s.X-0.5-support))+1, int(math.Ceil(s.X-0.5+support))
+			y0, y1 := int(math.Floor(s.Y-0.5-support))+1, int(math.Ceil(s.Y-0.5+support))
+
+			if x1 < 0 || y1 < 0 || x0 >= ib.w || y0 >= ib.h {
+				continue
+			}
+
+			var (
+				sum    [4]float64
+				weight float64
+			)
+
+			for sy := y0; sy <= y1; sy++ {
+				wy := filter.Kernel(float64(sy) + 0.5 - s.Y)
+
+				if wy == 0 {
+					continue
+				}
+
+				for sx := x0; sx <= x1; sx++ {
+					w := wy * filter.Kernel(float64(sx)+0.5-s.X)
+
+					if w == 0 {
+						continue
+					}
+
+					weight += w
+
+					// Pixels outside of the src image are transparent.
+					if sx < 0 || sy < 0 || sx >= ib.w || sy >= ib.h {
+						continue
+					}
+
+					p := ib.pix[sy*ib.w+sx]
+
+					sum[0] += p[0] * w
+					sum[1] += p[1] * w
+					sum[2] += p[2] * w
+					sum[3] += p[3] * w
+				}
+			}
+
+			if weight == 0 || sum[3] <= 0 {
+				continue
+			}
+
+			for i := range sum {
+				sum[i] /= weight
+			}
+
+			Mix(dst, x, y, colorFromLinearPremultiplied(sum))
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package gfx
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestDrawTransformed(t *testing.T) {
+	src := NewNRGBA(IR(0, 0, 2, 1))
+
+	src.SetNRGBA(0, 0, ColorNRGBA(255, 0, 0, 255))
+	src.SetNRGBA(1, 0, ColorNRGBA(0, 0, 255, 255))
+
+	t.Run("Nearest", func(t *testing.T) {
+		dst := NewNRGBA(IR(0, 0, 4, 4))
+
+		// Rotate by 90 degrees and scale by 2, around the origin, then move into view.
+		m := IM.Scaled(ZV, 2).Rotated(ZV, math.Pi/2).Moved(V(2, 0))
+
+		DrawTransformed(dst, src, m, BoxResampleFilter)
+
+		for _, tc := range []struct {
+			x, y int
+			want color.NRGBA
+		}{
+			{0, 0, ColorNRGBA(255, 0, 0, 255)},
+			{1, 1, ColorNRGBA(255, 0, 0, 255)},
+			{0, 3, ColorNRGBA(0, 0, 255, 255)},
+			{2, 0, ColorTransparent},
+		} {
+			if got := dst.NRGBAAt(tc.x, tc.y); got != tc.want {
+				t.Fatalf("dst.NRGBAAt(%d, %d) = %v, want %v", tc.x, tc.y, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("SourceOver", func(t *testing.T) {
+		dst := NewNRGBA(IR(0, 0, 2, 1))
+
+		DrawColor(dst, dst.Bounds(), ColorWhite)
+
+		half := NewNRGBA(IR(0, 0, 1, 1))
+
+		half.SetNRGBA(0, 0, ColorNRGBA(0, 0, 0, 128))
+
+		DrawTransformed(dst, half, IM.Moved(V(1, 0)), BilinearResampleFilter)
+
+		if got, want := dst.NRGBAAt(0, 0), ColorWhite; got != want {
+			t.Fatalf("dst.NRGBAAt(0, 0) = %v, want %v", got, want)
+		}
+
+		if got, want := dst.NRGBAAt(1, 0), ColorNRGBA(127, 127, 127, 255); got != want {
+			t.Fatalf("dst.NRGBAAt(1, 0) = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("DrawTarget", func(t *testing.T) {
+		dst := NewNRGBA(IR(0, 0, 4, 2))
+
+		dt := NewDrawTarget(dst)
+
+		dt.SetMatrix(IM.Moved(V(2, 1)))
+		dt.DrawImage(src, CatmullRomResampleFilter)
+
+		if got, want := dst.NRGBAAt(3, 1), ColorNRGBA(0, 0, 255, 255); got != want {
+			t.Fatalf("dst.NRGBAAt(3, 1) = %v, want %v", got, want)
+		}
+
+		if got, want := dst.NRGBAAt(0, 0), ColorTransparent; got != want {
+			t.Fatalf("dst.NRGBAAt(0, 0) = %v, want %v", got, want)
+		}
+	})
+}
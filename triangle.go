@@ -22,6 +22,10 @@ func NewTriangle(i int, td *TrianglesData) Triangle {
 	t[1].Color = td.Color(i + 1)
 	t[2].Color = td.Color(i + 2)
 
+	t[0].Picture, t[0].Intensity = td.Picture(i)
+	t[1].Picture, t[1].Intensity = td.Picture(i + 1)
+	t[2].Picture, t[2].Intensity = td.Picture(i + 2)
+
 	return t
 }
 
@@ -87,6 +91,22 @@ func (t Triangle) Contains(u Vec) bool {
 	return bs >= 0 && bt >= 0 && bs+bt <= 1
 }
 
+// Barycentric returns the barycentric coordinates of vector u
+// in relation to the three positions of the triangle.
+func (t Triangle) Barycentric(u Vec) (float64, float64, float64) {
+	a, b, c := t.Positions()
+
+	vs1 := b.Sub(a)
+	vs2 := c.Sub(a)
+
+	q := u.Sub(a)
+
+	bs := q.Cross(vs2) / vs1.Cross(vs2)
+	bt := vs1.Cross(q) / vs1.Cross(vs2)
+
+	return 1 - bs - bt, bs, bt
+}
+
 func triangleContains(u, a, b, c Vec) bool {
 	vs1 := b.Sub(a)
 	vs2 := c.Sub(a)
@@ -141,15 +161,44 @@ func (t Triangle) DrawOver(dst draw.Image) (drawCount int) {
 
 // DrawColor draws the triangle on dst using the given color.
 func (t Triangle) DrawColor(dst draw.Image, c color.Color) (drawCount int) {
-	return t.drawColor(dst, c, draw.Src)
+	return t.drawColor(dst, c, SrcBlendMode)
 }
 
 // DrawColorOver draws the triangle over dst using the given color.
 func (t Triangle) DrawColorOver(dst draw.Image, c color.Color) (drawCount int) {
-	return t.drawColor(dst, c, draw.Over)
+	return t.drawColor(dst, c, SrcOverBlendMode)
+}
+
+// DrawColorBlend draws the triangle on dst using the given color and blend mode.
+func (t Triangle) DrawColorBlend(dst draw.Image, c color.Color, bm BlendMode) (drawCount int) {
+	return t.drawColor(dst, c, bm)
+}
+
+// DrawAA draws the first color in the anti-aliased triangle over dst.
+func (t Triangle) DrawAA(dst draw.Image) (drawCount int) {
+	a, _, _ := t.Colors()
+
+	return t.DrawColorAA(dst, a)
+}
+
+// DrawColorAA draws the anti-aliased triangle over dst using the given color.
+func (t Triangle) DrawColorAA(dst draw.Image, c color.Color) (drawCount int) {
+	r := rasterBounds(t.Rect(), dst)
+
+	if r.Empty() {
+		return
+	}
+
+	a, b, d := t.Positions()
+
+	rz := NewRasterizer(r)
+
+	rz.AddPolygon(Polygon{a, b, d})
+
+	return rz.Draw(dst, c)
 }
 
-func (t Triangle) drawColor(dst draw.Image, c color.Color, op draw.Op) (drawCount int) {
+func (t Triangle) drawColor(dst draw.Image, c color.Color, bm BlendMode) (drawCount int) {
 	b := t.Bounds()
 
 	var lefts, rights []Vec
@@ -183,7 +232,7 @@ func (t Triangle) drawColor(dst draw.Image, c color.Color, op draw.Op) (drawCoun
 	for i := 0; i < len(lefts); i++ {
 		r := NewRect(lefts[i], rights[i].AddXY(0, 1)).Bounds()
 
-		draw.Draw(dst, r, uc, ZP, op)
+		DrawBlend(dst, r, uc, ZP, bm)
 
 		drawCount++
 	}
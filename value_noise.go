@@ -0,0 +1,42 @@
+package gfx
+
+import "math/rand"
+
+// ValueNoise is value noise for 2D, 3D and 4D, smoothly interpolating
+// random values (range [-1, 1]) assigned to each point of the lattice.
+type ValueNoise struct {
+	perm   []uint8
+	values [256]float64
+}
+
+// NewValueNoise creates a new value noise instance with the given seed
+func NewValueNoise(seed int64) *ValueNoise {
+	vn := &ValueNoise{perm: noisePermutation(seed)}
+
+	r := rand.New(rand.NewSource(seed))
+
+	for i := range vn.values {
+		vn.values[i] = r.Float64()*2 - 1
+	}
+
+	return vn
+}
+
+// Noise2D performs 2D value noise
+func (vn *ValueNoise) Noise2D(x, y float64) float64 {
+	return noiseLattice(vn.perm, 2, [4]float64{x, y}, vn.value)
+}
+
+// Noise3D performs 3D value noise
+func (vn *ValueNoise) Noise3D(x, y, z float64) float64 {
+	return noiseLattice(vn.perm, 3, [4]float64{x, y, z}, vn.value)
+}
+
+// Noise4D performs 4D value noise
+func (vn *ValueNoise) Noise4D(x, y, z, w float64) float64 {
+	return noiseLattice(vn.perm, 4, [4]float64{x, y, z, w}, vn.value)
+}
+
+func (vn *ValueNoise) value(h int, _ [4]float64) float64 {
+	return vn.values[h]
+}
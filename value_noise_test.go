@@ -0,0 +1,29 @@
+package gfx
+
+import "testing"
+
+func TestValueNoise(t *testing.T) {
+	vn := NewValueNoise(1234)
+
+	if got, want := vn.Noise2D(0, 0), vn.values[vn.perm[vn.perm[0]]]; got != want {
+		t.Fatalf("vn.Noise2D(0, 0) = %v, want %v", got, want)
+	}
+
+	if got, want := vn.Noise3D(2.5, 1.5, 0.5), NewValueNoise(1234).Noise3D(2.5, 1.5, 0.5); got != want {
+		t.Fatalf("vn.Noise3D(2.5, 1.5, 0.5) = %v, want %v", got, want)
+	}
+
+	for y := -3.0; y < 3; y += 0.31 {
+		for x := -3.0; x < 3; x += 0.23 {
+			for _, v := range []float64{
+				vn.Noise2D(x, y),
+				vn.Noise3D(x, y, x*y),
+				vn.Noise4D(x, y, x-y, x+y),
+			} {
+				if v < -1 || v > 1 {
+					t.Fatalf("noise at (%v, %v) = %v, want value in range [-1, 1]", x, y, v)
+				}
+			}
+		}
+	}
+}
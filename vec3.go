@@ -113,6 +113,42 @@ func (u Vec3) Dot(v Vec3) float64 {
 	return u.X*v.X + u.Y*v.Y + u.Z*v.Z
 }
 
+// Cross returns the cross product of vectors u and v.
+func (u Vec3) Cross(v Vec3) Vec3 {
+	return Vec3{
+		u.Y*v.Z - u.Z*v.Y,
+		u.Z*v.X - u.X*v.Z,
+		u.X*v.Y - u.Y*v.X,
+	}
+}
+
+// Abs returns the absolute vector of the vector u.
+func (u Vec3) Abs() Vec3 {
+	return Vec3{
+		math.Abs(u.X),
+		math.Abs(u.Y),
+		math.Abs(u.Z),
+	}
+}
+
+// Max returns the maximum vector of u and v.
+func (u Vec3) Max(v Vec3) Vec3 {
+	return Vec3{
+		math.Max(u.X, v.X),
+		math.Max(u.Y, v.Y),
+		math.Max(u.Z, v.Z),
+	}
+}
+
+// Min returns the minimum vector of u and v.
+func (u Vec3) Min(v Vec3) Vec3 {
+	return Vec3{
+		math.Min(u.X, v.X),
+		math.Min(u.Y, v.Y),
+		math.Min(u.Z, v.Z),
+	}
+}
+
 // SqDist returns the square of the euclidian distance between two vectors.
 func (u Vec3) SqDist(v Vec3) float64 {
 	return u.Sub(v).SqLen()
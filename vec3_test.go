@@ -255,3 +255,35 @@ func TestVec3Lerp(t *testing.T) {
 		}
 	}
 }
+
+func TestVec3Cross(t *testing.T) {
+	for _, tc := range []struct {
+		u    Vec3
+		v    Vec3
+		want Vec3
+	}{
+		{V3(1, 0, 0), V3(0, 1, 0), V3(0, 0, 1)},
+		{V3(0, 1, 0), V3(1, 0, 0), V3(0, 0, -1)},
+		{V3(1, 2, 3), V3(4, 5, 6), V3(-3, 6, -3)},
+	} {
+		if got := tc.u.Cross(tc.v); !got.Eq(tc.want) {
+			t.Fatalf("u.Cross(%v) = %v, want %v", tc.v, got, tc.want)
+		}
+	}
+}
+
+func TestVec3AbsMaxMin(t *testing.T) {
+	u, v := V3(-1, 2, -3), V3(0, -4, 5)
+
+	if got, want := u.Abs(), V3(1, 2, 3); !got.Eq(want) {
+		t.Fatalf("u.Abs() = %v, want %v", got, want)
+	}
+
+	if got, want := u.Max(v), V3(0, 2, 5); !got.Eq(want) {
+		t.Fatalf("u.Max(%v) = %v, want %v", v, got, want)
+	}
+
+	if got, want := u.Min(v), V3(-1, -4, -3); !got.Eq(want) {
+		t.Fatalf("u.Min(%v) = %v, want %v", v, got, want)
+	}
+}
@@ -0,0 +1,144 @@
+package gfx
+
+import "math"
+
+// DistanceMetric is the metric used to measure distances.
+type DistanceMetric int
+
+// Distance metrics
+const (
+	EuclideanDistanceMetric DistanceMetric = iota
+	ManhattanDistanceMetric
+	ChebyshevDistanceMetric
+)
+
+// Distance returns the length of the vector u using the metric.
+func (m DistanceMetric) Distance(u Vec3) float64 {
+	switch m {
+	case ManhattanDistanceMetric:
+		return math.Abs(u.X) + math.Abs(u.Y) + math.Abs(u.Z)
+	case ChebyshevDistanceMetric:
+		return math.Max(math.Abs(u.X), math.Max(math.Abs(u.Y), math.Abs(u.Z)))
+	default:
+		return u.Len()
+	}
+}
+
+// WorleyFeature is the result of Worley noise at a point, with the distances
+// to the closest (F1) and second closest (F2) feature points, and the ID of
+// the cell containing the closest feature point.
+type WorleyFeature struct {
+	F1 float64
+	F2 float64
+	ID uint32
+}
+
+// WorleyNoise is Worley (cellular) noise for 2D and 3D, with a
+// randomly placed feature point in each cell of the integer lattice.
+//
+// Distances are measured using the Metric.
+type WorleyNoise struct {
+	Metric DistanceMetric
+
+	seed uint64
+}
+
+// NewWorleyNoise creates a new Worley noise instance with the given seed
+func NewWorleyNoise(seed int64) *WorleyNoise {
+	return &WorleyNoise{seed: uint64(seed)}
+}
+
+// Noise2D returns the F1 distance of 2D Worley noise
+func (wn *WorleyNoise) Noise2D(x, y float64) float64 {
+	return wn.Worley2D(x, y).F1
+}
+
+// Noise3D returns the F1 distance of 3D Worley noise
+func (wn *WorleyNoise) Noise3D(x, y, z float64) float64 {
+	return wn.Worley3D(x, y, z).F1
+}
+
+// Worley2D performs 2D Worley noise
+func (wn *WorleyNoise) Worley2D(x, y float64) WorleyFeature {
+	return wn.worley(V3(x, y, 0), 0)
+}
+
+// Worley3D performs 3D Worley noise
+func (wn *WorleyNoise) Worley3D(x, y, z float64) WorleyFeature {
+	return wn.worley(V3(x, y, z), 1)
+}
+
+// worley searches the cells around p in growing rings, with dz being 0 for
+// 2D (where only the cells at the Z of p are searched) and 1 for 3D.
+//
+// The feature points in cells outside of a ring of radius r are at least r
+// away from p along one of the axes, and none of the metrics are shorter
+// than that, so the search stops when F2 is within r. (F1 and F2 are exact)
+func (wn *WorleyNoise) worley(p Vec3, dz int) WorleyFeature {
+	cx, cy, cz := int(math.Floor(p.X)), int(math.Floor(p.Y)), int(math.Floor(p.Z))
+
+	wf := WorleyFeature{F1: math.Inf(1), F2: math.Inf(1)}
+
+	for r := 0; ; r++ {
+		rz := r * dz
+
+		for z := cz - rz; z <= cz+rz; z++ {
+			for y := cy - r; y <= cy+r; y++ {
+				for x := cx - r; x <= cx+r; x++ {
+					// Skip the cells of the inner rings.
+					if IntMax(IntAbs(x-cx), IntMax(IntAbs(y-cy), IntAbs(z-cz))) != r {
+						continue
+					}
+
+					h := worleyHash(wn.seed, x, y, z)
+
+					fp := V3(
+						float64(x)+worleyUnit(h),
+						float64(y)+worleyUnit(worleyMix(h+1)),
+						float64(z),
+					)
+
+					if dz > 0 {
+						fp.Z += worleyUnit(worleyMix(h + 2))
+					}
+
+					d := wn.Metric.Distance(fp.Sub(p))
+
+					switch {
+					case d < wf.F1:
+						wf.F2, wf.F1, wf.ID = wf.F1, d, uint32(h)
+					case d < wf.F2:
+						wf.F2 = d
+					}
+				}
+			}
+		}
+
+		if r > 0 && wf.F2 <= float64(r) {
+			return wf
+		}
+	}
+}
+
+// worleyHash returns a hash of the seed and the cell coordinates.
+func worleyHash(seed uint64, x, y, z int) uint64 {
+	h := worleyMix(seed ^ uint64(int64(x))*0x9E3779B97F4A7C15)
+
+	h = worleyMix(h ^ uint64(int64(y))*0xC2B2AE3D27D4EB4F)
+
+	return worleyMix(h ^ uint64(int64(z))*0x165667B19E3779F9)
+}
+
+// worleyMix is the finalizer of the SplitMix64 generator.
+func worleyMix(h uint64) uint64 {
+	h += 0x9E3779B97F4A7C15
+	h = (h ^ h>>30) * 0xBF58476D1CE4E5B9
+	h = (h ^ h>>27) * 0x94D049BB133111EB
+
+	return h ^ h>>31
+}
+
+// worleyUnit returns a value in the range [0, 1) based on the hash.
+func worleyUnit(h uint64) float64 {
+	return float64(h>>11) / (1 << 53)
+}
@@ -0,0 +1,98 @@
+package gfx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistanceMetricDistance(t *testing.T) {
+	u := V3(3, -4, 1)
+
+	for _, tc := range []struct {
+		m    DistanceMetric
+		want float64
+	}{
+		{EuclideanDistanceMetric, math.Sqrt(26)},
+		{ManhattanDistanceMetric, 8},
+		{ChebyshevDistanceMetric, 4},
+	} {
+		if got := tc.m.Distance(u); got != tc.want {
+			t.Fatalf("m.Distance(%v) = %v, want %v", u, got, tc.want)
+		}
+	}
+}
+
+func TestWorleyNoise(t *testing.T) {
+	wn := NewWorleyNoise(1234)
+
+	for y := -3.0; y < 3; y += 0.17 {
+		for x := -3.0; x < 3; x += 0.13 {
+			wf := wn.Worley2D(x, y)
+
+			if wf.F1 < 0 || wf.F1 > wf.F2 || wf.F2 > 2*math.Sqrt2 {
+				t.Fatalf("wn.Worley2D(%v, %v) = %+v", x, y, wf)
+			}
+
+			if got := wn.Noise2D(x, y); got != wf.F1 {
+				t.Fatalf("wn.Noise2D(%v, %v) = %v, want %v", x, y, got, wf.F1)
+			}
+
+			w3 := wn.Worley3D(x, y, x+y)
+
+			if w3.F1 < 0 || w3.F1 > w3.F2 {
+				t.Fatalf("wn.Worley3D(%v, %v, %v) = %+v", x, y, x+y, w3)
+			}
+
+			manhattan := (&WorleyNoise{Metric: ManhattanDistanceMetric, seed: wn.seed}).Worley2D(x, y)
+			chebyshev := (&WorleyNoise{Metric: ChebyshevDistanceMetric, seed: wn.seed}).Worley2D(x, y)
+
+			if !(chebyshev.F1 <= wf.F1 && wf.F1 <= manhattan.F1) {
+				t.Fatalf("F1 chebyshev = %v, euclidean = %v, manhattan = %v", chebyshev.F1, wf.F1, manhattan.F1)
+			}
+		}
+	}
+
+	t.Run("ID", func(t *testing.T) {
+		wf := wn.Worley2D(0.5, 0.5)
+
+		if got := NewWorleyNoise(1234).Worley2D(0.5, 0.5); got != wf {
+			t.Fatalf("Worley2D(0.5, 0.5) = %+v, want %+v", got, wf)
+		}
+
+		if got := NewWorleyNoise(4321).Worley2D(0.5, 0.5); got.ID == wf.ID {
+			t.Fatalf("expected different cell IDs for different seeds")
+		}
+	})
+}
+
+func TestWorleyNoiseExact(t *testing.T) {
+	// Compare with a brute force search of the 9×9 cells around each point.
+	for _, m := range []DistanceMetric{EuclideanDistanceMetric, ManhattanDistanceMetric, ChebyshevDistanceMetric} {
+		wn := &WorleyNoise{Metric: m, seed: 42}
+
+		for y := -4.0; y < 4; y += 0.11 {
+			for x := -4.0; x < 4; x += 0.07 {
+				want := WorleyFeature{F1: math.Inf(1), F2: math.Inf(1)}
+
+				for cy := int(math.Floor(y)) - 4; cy <= int(math.Floor(y))+4; cy++ {
+					for cx := int(math.Floor(x)) - 4; cx <= int(math.Floor(x))+4; cx++ {
+						h := worleyHash(wn.seed, cx, cy, 0)
+
+						d := m.Distance(V3(float64(cx)+worleyUnit(h)-x, float64(cy)+worleyUnit(worleyMix(h+1))-y, 0))
+
+						switch {
+						case d < want.F1:
+							want.F2, want.F1, want.ID = want.F1, d, uint32(h)
+						case d < want.F2:
+							want.F2 = d
+						}
+					}
+				}
+
+				if got := wn.Worley2D(x, y); got != want {
+					t.Fatalf("metric %d: wn.Worley2D(%v, %v) = %+v, want %+v", m, x, y, got, want)
+				}
+			}
+		}
+	}
+}
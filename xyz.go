@@ -12,24 +12,11 @@ import (
 func ColorToXYZ(c color.Color) XYZ {
 	r, g, b := floatRGB(c)
 
-	if r > 0.04045 {
-		r = math.Pow((r+0.055)/1.055, 2.4)
-	} else {
-		r = r / 12.92
-	}
-
-	if g > 0.04045 {
-		g = math.Pow((g+0.055)/1.055, 2.4)
-	} else {
-		g = g / 12.92
-	}
-
-	if b > 0.04045 {
-		b = math.Pow((b+0.055)/1.055, 2.4)
-	} else {
-		b = b / 12.92
-	}
+	return linearRGBToXYZ(sRGBToLinear(r), sRGBToLinear(g), sRGBToLinear(b))
+}
 
+// linearRGBToXYZ converts linear R, G and B in the range [0, 1] into XYZ (D65/2°).
+func linearRGBToXYZ(r, g, b float64) XYZ {
 	r = r * 100.0
 	g = g * 100.0
 	b = b * 100.0
@@ -41,6 +28,44 @@ func ColorToXYZ(c color.Color) XYZ {
 	}
 }
 
+// xyzToLinearRGB is the inverse of the matrix used by linearRGBToXYZ.
+var xyzToLinearRGB = [3][3]float64{
+	{3.2406254773200533, -1.5372079722103187, -0.4986285986982479},
+	{-0.9689307147293194, 1.875756060885241, 0.04151752384295394},
+	{0.05571012044551061, -0.2040210505984867, 1.0569959422543882},
+}
+
+// linearRGB converts from XYZ (D65/2°) into linear R, G and B in the range [0, 1].
+func (xyz XYZ) linearRGB() (r, g, b float64) {
+	x, y, z := xyz.X/100, xyz.Y/100, xyz.Z/100
+
+	m := xyzToLinearRGB
+
+	r = x*m[0][0] + y*m[0][1] + z*m[0][2]
+	g = x*m[1][0] + y*m[1][1] + z*m[1][2]
+	b = x*m[2][0] + y*m[2][1] + z*m[2][2]
+
+	return r, g, b
+}
+
+// sRGBToLinear converts a sRGB component in the range [0, 1] into linear light.
+func sRGBToLinear(v float64) float64 {
+	if v > 0.04045 {
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+
+	return v / 12.92
+}
+
+// linearToSRGB converts a linear light component in the range [0, 1] into sRGB.
+func linearToSRGB(v float64) float64 {
+	if v > 0.0031308 {
+		return 1.055*math.Pow(v, 1/2.4) - 0.055
+	}
+
+	return v * 12.92
+}
+
 // XYZ color space.
 type XYZ struct {
 	X float64